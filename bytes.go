@@ -0,0 +1,34 @@
+package stringsort
+
+import "sort"
+
+// ByMixedKeyBytes returns a sorter that orders bs non-decreasing by mixed
+// key, in the same order ByMixedKey would give the corresponding strings.
+// The keys are precomputed at the point of construction, from copies of the
+// contents of bs, so they do not refer to the underlying arrays of bs.
+func ByMixedKeyBytes(bs [][]byte) sort.Interface {
+	kp := byMixedKeyBytes{
+		bs: bs,
+		byMixedKey: byMixedKey{
+			ss:   make([]string, len(bs)),
+			keys: make([]MixedKey, len(bs)),
+		},
+	}
+	for i, b := range bs {
+		kp.ss[i] = string(b)
+		kp.keys[i] = ParseMixed(kp.ss[i])
+	}
+	return kp
+}
+
+// byMixedKeyBytes implements sort.Interface for byte slices using the mixed
+// keys of copies of their contents.
+type byMixedKeyBytes struct {
+	bs [][]byte // the original slice to be sorted
+	byMixedKey
+}
+
+func (b byMixedKeyBytes) Swap(i, j int) {
+	b.bs[i], b.bs[j] = b.bs[j], b.bs[i]
+	b.byMixedKey.Swap(i, j)
+}
@@ -0,0 +1,56 @@
+package stringsort
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestByMixedKeyBytes(t *testing.T) {
+	input := []string{"b10", "a2", "", "b1", "a10", "b01", "a1", "b1", "café9"}
+	want := copyStrings(input)
+	sort.Sort(ByMixedKey(want))
+
+	bs := make([][]byte, len(input))
+	for i, s := range input {
+		bs[i] = []byte(s)
+	}
+	bs = append(bs, nil)
+	want = append([]string{""}, want...)
+
+	sort.Sort(ByMixedKeyBytes(bs))
+	got := make([]string, len(bs))
+	for i, b := range bs {
+		got[i] = string(b)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKeyBytes: (-want, +got):\n%s", diff)
+	}
+}
+
+func BenchmarkByMixedKeyBytes(b *testing.B) {
+	input := benchInput(10000)
+	bs := make([][]byte, len(input))
+	for i, s := range input {
+		bs[i] = []byte(s)
+	}
+	buf := make([][]byte, len(bs))
+	b.Run("Convert", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ss := make([]string, len(bs))
+			for j, v := range bs {
+				ss[j] = string(v)
+			}
+			sort.Sort(ByMixedKey(ss))
+		}
+	})
+	b.Run("Bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			copy(buf, bs)
+			sort.Sort(ByMixedKeyBytes(buf))
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package stringsort
+
+import "fmt"
+
+// CheckOrder reports whether compare is a consistent ordering of the strings
+// in ss, as sort.Sort and the other sorting functions of this package require.
+// The compare function should return a negative, zero, or positive value if a
+// should sort before, the same as, or after b. If the ordering is consistent,
+// CheckOrder returns nil; otherwise it returns an error describing a pair of
+// strings that witnesses the inconsistency, for example:
+//
+//	ordering is not transitive: "b" < "a" by a chain of comparisons, but compare("b", "a") = 1
+//
+// An ordering is consistent if it is antisymmetric, so that compare(a, b) and
+// compare(b, a) have opposite signs, and transitive, so that a < b and b < c
+// imply a < c, and a == b and b == c imply a == c. Orderings built from
+// custom collators, tie-breakers, or transforms can easily fail to be
+// transitive, and sorting with such an ordering gives an arbitrary result.
+//
+// CheckOrder calls compare O(n²) times for n strings, and is meant for
+// diagnostic use, such as in tests, not for routine use before sorting. The
+// contents of ss are not modified.
+func CheckOrder(ss []string, compare func(a, b string) int) error {
+	return checkOrder(ss, func(i, j int) int { return compare(ss[i], ss[j]) })
+}
+
+// CheckOrder reports whether the order defined by o is consistent for the
+// strings in ss, as described for the CheckOrder function. The strings are
+// compared as by the Compare method, including any tie-breakers, but each
+// string is parsed only once.
+func (o *Options) CheckOrder(ss []string) error {
+	kp := o.byMixedKey(ss)
+	return checkOrder(ss, func(i, j int) int {
+		if ss[i] == ss[j] {
+			return 0
+		}
+		return o.compareStrings(ss[i], ss[j], kp.keys[i], kp.keys[j])
+	})
+}
+
+// checkOrder reports whether compare is a consistent ordering of the strings
+// in ss, where compare(i, j) compares ss[i] and ss[j].
+//
+// The strings are first put in order by an insertion sort, which terminates
+// even if the ordering is inconsistent, and adjacent strings that compare
+// equal are grouped together. The ordering is consistent if and only if every
+// pair of strings compares as their positions and groups require: equal if
+// they are in the same group, and otherwise in the order of their positions.
+func checkOrder(ss []string, compare func(i, j int) int) error {
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+		for j := i; j > 0 && compare(idx[j-1], idx[j]) > 0; j-- {
+			idx[j-1], idx[j] = idx[j], idx[j-1]
+		}
+	}
+	group := make([]int, len(idx))
+	for x := 1; x < len(idx); x++ {
+		group[x] = group[x-1]
+		if compare(idx[x-1], idx[x]) != 0 {
+			group[x]++
+		}
+	}
+	for x, i := range idx {
+		for y := x + 1; y < len(idx); y++ {
+			j := idx[y]
+			cij, cji := compare(i, j), compare(j, i)
+			if sign(cij) != -sign(cji) {
+				return fmt.Errorf("ordering is not antisymmetric: compare(%q, %q) = %d, but compare(%q, %q) = %d",
+					ss[i], ss[j], cij, ss[j], ss[i], cji)
+			} else if group[x] == group[y] && cij != 0 {
+				return fmt.Errorf("ordering is not transitive: %q == %q by a chain of comparisons, but compare(%q, %q) = %d",
+					ss[i], ss[j], ss[i], ss[j], cij)
+			} else if group[x] != group[y] && cij >= 0 {
+				return fmt.Errorf("ordering is not transitive: %q < %q by a chain of comparisons, but compare(%q, %q) = %d",
+					ss[i], ss[j], ss[i], ss[j], cij)
+			}
+		}
+	}
+	return nil
+}
+
+func sign(v int) int {
+	if v < 0 {
+		return -1
+	} else if v > 0 {
+		return 1
+	}
+	return 0
+}
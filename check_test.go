@@ -0,0 +1,105 @@
+package stringsort
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+func TestCheckOrder(t *testing.T) {
+	// A rock-paper-scissors ordering, in which no string is least.
+	cyclic := func(a, b string) int {
+		switch a + b {
+		case "rs", "sp", "pr":
+			return -1
+		case "sr", "ps", "rp":
+			return 1
+		}
+		return 0
+	}
+	// An ordering in which strings of similar length are equal, so that
+	// equality is not transitive.
+	similar := func(a, b string) int {
+		if d := len(a) - len(b); d < -1 {
+			return -1
+		} else if d > 1 {
+			return 1
+		}
+		return 0
+	}
+	// An ordering in which every string sorts before every other.
+	greedy := func(a, b string) int {
+		if a == b {
+			return 0
+		}
+		return -1
+	}
+
+	tests := []struct {
+		input   []string
+		compare func(a, b string) int
+		want    string // error text, or "" for success
+	}{
+		{nil, cyclic, ""},
+		{[]string{"r"}, cyclic, ""},
+		{[]string{"r", "s"}, cyclic, ""},
+		{[]string{"r", "s", "p"}, cyclic, "not transitive"},
+		{[]string{"a", "bb"}, similar, ""},
+		{[]string{"a", "bb", "c", "dd"}, similar, ""},
+		{[]string{"a", "bb", "ccc"}, similar, "not transitive"},
+		{[]string{"a", "b"}, greedy, "not antisymmetric"},
+		{benchInput(100), Compare, ""},
+		{benchInput(100), strings.Compare, ""},
+	}
+	for _, test := range tests {
+		input := copyStrings(test.input)
+		err := CheckOrder(input, test.compare)
+		if test.want == "" && err != nil {
+			t.Errorf("CheckOrder(%q): unexpected error: %v", test.input, err)
+		} else if test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)) {
+			t.Errorf("CheckOrder(%q): got error %v, want %q", test.input, err, test.want)
+		}
+		for i, s := range test.input {
+			if input[i] != s {
+				t.Errorf("CheckOrder(%q) modified its input", test.input)
+				break
+			}
+		}
+	}
+}
+
+func TestOptionsCheckOrder(t *testing.T) {
+	input := append(benchInput(50), "a1", "A1", "á1", "Á01", "a01", "b1", "B2", "file10", "FILE9")
+	tests := []struct {
+		name string
+		opts *Options
+		want string
+	}{
+		{"Default", nil, ""},
+		{"FoldCase", &Options{FoldCase: true, FoldAccents: true}, ""},
+		{"Collator", &Options{Collator: collate.New(language.English, collate.IgnoreCase)}, ""},
+		{"TieBreak", &Options{FoldCase: true, TieBreak: func(a, b string) int {
+			// Reverse lexicographic order, except that "A1" < "a1". With
+			// "a01", which has an equal key, this makes a cycle.
+			if a+b == "A1a1" {
+				return -1
+			} else if a+b == "a1A1" {
+				return 1
+			}
+			return strings.Compare(b, a)
+		}}, "not transitive"},
+		{"Asymmetric", &Options{FoldCase: true, TieBreak: func(a, b string) int { return -1 }}, "not antisymmetric"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.opts.CheckOrder(input)
+			if test.want == "" && err != nil {
+				t.Errorf("CheckOrder: unexpected error: %v", err)
+			} else if test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)) {
+				t.Errorf("CheckOrder: got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package stringsort
+
+import "sort"
+
+// A Comparer defines an order on strings. Compare returns a negative value
+// if a sorts before b, zero if a and b sort in the same position, or a
+// positive value if a sorts after b. A Comparer can be passed to generic
+// data structures and algorithms in place of a comparison function, for
+// example slices.SortFunc(ss, c.Compare).
+//
+// The *Options type implements Comparer, where a nil *Options gives the
+// default order of Compare and ByMixedKey, so any combination of options can
+// be used as a Comparer. The functions below return Comparers for the common
+// orders. Each call returns a new value, so a caller that modifies the
+// Comparer it gets does not affect any other caller.
+type Comparer interface {
+	Compare(a, b string) int
+}
+
+// ComparerFunc adapts an ordinary comparison function to the Comparer
+// interface.
+type ComparerFunc func(a, b string) int
+
+// Compare implements the Comparer interface by calling f(a, b).
+func (f ComparerFunc) Compare(a, b string) int { return f(a, b) }
+
+// DefaultComparer returns a Comparer for the order of Compare and ByMixedKey.
+func DefaultComparer() Comparer { return (*Options)(nil) }
+
+// FoldComparer returns a Comparer for the order of ByMixedKeyFold.
+func FoldComparer() Comparer { return &Options{FoldCase: true} }
+
+// DescendingComparer returns a Comparer for the order of ByMixedKeyDesc.
+func DescendingComparer() Comparer { return &Options{Descending: true} }
+
+// NumericComparer returns a Comparer for the order of ByNumericKey.
+func NumericComparer() Comparer { return numericComparer{} }
+
+// ByComparer returns a sorter that orders ss non-decreasing according to c.
+// If c is an *Options or was returned by NumericComparer, the keys of the
+// strings are precomputed at the point of construction, as by the
+// corresponding ByMixedKey method or function, and each comparison compares
+// the keys in the order of c.Compare without parsing the strings again.
+// Otherwise, c.Compare is called for each comparison, and it must break ties
+// itself if a deterministic order is needed.
+func ByComparer(ss []string, c Comparer) sort.Interface {
+	switch t := c.(type) {
+	case *Options:
+		return t.ByMixedKey(ss)
+	case numericComparer:
+		return ByNumericKey(ss)
+	}
+	return byComparer{ss: ss, c: c}
+}
+
+// byComparer implements sort.Interface for an arbitrary Comparer.
+type byComparer struct {
+	ss []string
+	c  Comparer
+}
+
+func (b byComparer) Len() int           { return len(b.ss) }
+func (b byComparer) Less(i, j int) bool { return b.c.Compare(b.ss[i], b.ss[j]) < 0 }
+func (b byComparer) Swap(i, j int)      { b.ss[i], b.ss[j] = b.ss[j], b.ss[i] }
+
+// numericComparer implements Comparer for the order of ByNumericKey.
+type numericComparer struct{}
+
+func (numericComparer) Compare(a, b string) int {
+	if a == b {
+		return 0
+	}
+	ka, kb := numericKey(ParseMixed(a)), numericKey(ParseMixed(b))
+	return (*Options)(nil).compareStrings(a, b, ka, kb)
+}
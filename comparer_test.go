@@ -0,0 +1,61 @@
+package stringsort
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestComparers(t *testing.T) {
+	input := append(benchInput(50), "", "a", "A1", "a01", "a1", "b2", "B10", "x 10 y", "10x", "x2")
+	tests := []struct {
+		name   string
+		c      Comparer
+		sorter func([]string) sort.Interface
+	}{
+		{"Default", DefaultComparer(), ByMixedKey},
+		{"Fold", FoldComparer(), ByMixedKeyFold},
+		{"Descending", DescendingComparer(), ByMixedKeyDesc},
+		{"Numeric", NumericComparer(), ByNumericKey},
+		{"Options", &Options{Signed: true, SplitExtension: true}, (&Options{Signed: true, SplitExtension: true}).ByMixedKey},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want := copyStrings(input)
+			sort.Sort(test.sorter(want))
+
+			// Sorting with the Compare method gives the same order.
+			got := copyStrings(input)
+			slices.SortFunc(got, test.c.Compare)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("SortFunc: (-want, +got):\n%s", diff)
+			}
+
+			// As does sorting with ByComparer.
+			got = copyStrings(input)
+			sort.Sort(ByComparer(got, test.c))
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ByComparer: (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestComparerFunc(t *testing.T) {
+	// A comparer that orders strings by length, and then by mixed key.
+	var c Comparer = ComparerFunc(func(a, b string) int {
+		if c := compareInt(len(a), len(b)); c != 0 {
+			return c
+		}
+		return Compare(a, b)
+	})
+	input := strings.Fields("file10 b file2 a10 a9 c")
+	sort.Sort(ByComparer(input, c))
+	want := strings.Fields("b c a9 a10 file2 file10")
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByComparer: (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,70 @@
+package stringsort
+
+import (
+	"context"
+	"sort"
+)
+
+// checkInterval is the number of strings StringsContext processes between
+// checks for cancellation of its context.
+const checkInterval = 1024
+
+// StringsContext sorts ss in place, non-decreasing by mixed key, in the same
+// order as Strings. It checks ctx periodically while parsing keys and while
+// sorting, and if ctx ends before the sort is complete, StringsContext stops
+// and returns ctx.Err(). Otherwise it returns nil.
+//
+// If StringsContext returns an error, ss contains the same strings as before,
+// but in an unspecified order: No part of ss is guaranteed to be sorted, and
+// ss is not guaranteed to be in its original order.
+//
+// The input is sorted in pieces that are then merged pairwise, and ctx is
+// checked before each piece is sorted and before each pair is merged. Thus
+// the time between checks is proportional to len(ss) in the worst case, but
+// is bounded by the time to merge, rather than to sort, the whole input.
+// StringsContext uses additional memory proportional to len(ss).
+func StringsContext(ctx context.Context, ss []string) error {
+	src := byMixedKey{ss: ss, keys: make([]MixedKey, len(ss))}
+	for i, s := range ss {
+		if i%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		src.keys[i] = ParseMixed(s)
+	}
+
+	// Sort pieces of checkInterval strings each.
+	for lo := 0; lo < len(ss); lo += checkInterval {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := min(lo+checkInterval, len(ss))
+		sort.Sort(byMixedKey{ss: src.ss[lo:hi], keys: src.keys[lo:hi]})
+	}
+	if len(ss) <= checkInterval {
+		return nil
+	}
+
+	// Merge adjacent pairs of sorted pieces, alternating between the input
+	// and a buffer, until a single piece remains. If ctx ends during a pass,
+	// src still holds a complete permutation of the input.
+	dst := byMixedKey{ss: make([]string, len(ss)), keys: make([]MixedKey, len(ss))}
+	for width := checkInterval; width < len(ss); width *= 2 {
+		for lo := 0; lo < len(ss); lo += 2 * width {
+			if err := ctx.Err(); err != nil {
+				if &src.ss[0] != &ss[0] {
+					copy(ss, src.ss)
+				}
+				return err
+			}
+			mid, hi := min(lo+width, len(ss)), min(lo+2*width, len(ss))
+			mergeKeys(dst, src, lo, mid, hi)
+		}
+		src, dst = dst, src
+	}
+	if &src.ss[0] != &ss[0] {
+		copy(ss, src.ss)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package stringsort
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// expiringContext is a context that ends after its Err method has been
+// called a fixed number of times.
+type expiringContext struct {
+	context.Context
+	checks int // the number of remaining calls that report success
+}
+
+func (c *expiringContext) Err() error {
+	if c.checks <= 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestStringsContext(t *testing.T) {
+	for _, n := range []int{0, 1, 100, checkInterval, checkInterval + 1, 5*checkInterval + 17} {
+		input := benchInput(n)
+		want := copyStrings(input)
+		Strings(want)
+
+		got := copyStrings(input)
+		if err := StringsContext(context.Background(), got); err != nil {
+			t.Errorf("StringsContext (n=%d): unexpected error: %v", n, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("StringsContext (n=%d): (-want, +got):\n%s", n, diff)
+		}
+	}
+}
+
+func TestStringsContextCancel(t *testing.T) {
+	input := benchInput(5*checkInterval + 17)
+	want := copyStrings(input)
+	sort.Strings(want)
+
+	// Cancel the context at each point it is checked, whether while parsing,
+	// sorting, or merging, and verify the result is a permutation of the input.
+	for checks := 0; ; checks++ {
+		got := copyStrings(input)
+		err := StringsContext(&expiringContext{Context: context.Background(), checks: checks}, got)
+		if err == nil {
+			if !StringsAreSorted(got) {
+				t.Errorf("StringsContext (checks=%d): result is not sorted", checks)
+			}
+			break
+		} else if !errors.Is(err, context.Canceled) {
+			t.Fatalf("StringsContext (checks=%d): got error %v, want %v", checks, err, context.Canceled)
+		}
+		sort.Strings(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("StringsContext (checks=%d): result is not a permutation (-want, +got):\n%s", checks, diff)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package stringsort
+
+import (
+	"sort"
+	"strings"
+)
+
+// A DottedPad specifies how CompareDotted and ByDotted compare strings with
+// different numbers of components.
+type DottedPad int
+
+const (
+	PadNone DottedPad = iota // a prefix of components sorts first (default)
+	PadZero                  // missing components are treated as "0"
+)
+
+// CompareDotted compares a and b as dotted identifiers, such as IP addresses
+// or build numbers like "10.2.1.100". It returns -1 if a < b, 0 if a == b, or
+// +1 if a > b.
+//
+// Each string is split into components at each ".", and the components are
+// compared in turn. Components consisting only of ASCII digits compare by
+// numeric value, and sort before all other components, which compare by mixed
+// key as Compare does. For example, the following are in order:
+//
+//	1.2 1.2.9 1.2.10 1.10 1.x 10.2.1.100 10.10.1.2
+//
+// If the components of one string are a prefix of the components of the
+// other, the order depends on pad: With PadNone, the shorter string sorts
+// first. With PadZero, the missing components of the shorter string are
+// treated as "0", so that "1.2" and "1.2.0" compare equal but "1.2" sorts
+// before "1.2.1". Strings whose components are equal, such as "1.02" and
+// "1.2", are ordered by their lexicographic order.
+func CompareDotted(a, b string, pad DottedPad) int {
+	if a == b {
+		return 0
+	} else if c := compareDotted(strings.Split(a, "."), strings.Split(b, "."), pad); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// ByDotted returns a sorter that orders ss non-decreasing by dotted
+// identifier, in the order given by CompareDotted with the specified pad.
+func ByDotted(ss []string, pad DottedPad) sort.Interface {
+	b := byDotted{ss: ss, parts: make([][]string, len(ss)), pad: pad}
+	for i, s := range ss {
+		b.parts[i] = strings.Split(s, ".")
+	}
+	return b
+}
+
+// byDotted implements sort.Interface for dotted identifiers.
+type byDotted struct {
+	ss    []string   // the original slice to be sorted
+	parts [][]string // the components of each element of ss
+	pad   DottedPad
+}
+
+func (b byDotted) Len() int { return len(b.ss) }
+
+func (b byDotted) Less(i, j int) bool {
+	if c := compareDotted(b.parts[i], b.parts[j], b.pad); c != 0 {
+		return c < 0
+	}
+	return b.ss[i] < b.ss[j]
+}
+
+func (b byDotted) Swap(i, j int) {
+	b.ss[i], b.ss[j] = b.ss[j], b.ss[i]
+	b.parts[i], b.parts[j] = b.parts[j], b.parts[i]
+}
+
+// compareDotted compares the components of two dotted identifiers.
+func compareDotted(a, b []string, pad DottedPad) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if pad != PadZero && (i >= len(a) || i >= len(b)) {
+			return compareInt(len(a), len(b))
+		}
+		ca, cb := "0", "0"
+		if i < len(a) {
+			ca = a[i]
+		}
+		if i < len(b) {
+			cb = b[i]
+		}
+		if c := compareComponent(ca, cb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareComponent compares components of dotted identifiers. Numeric
+// components compare by value, and sort before non-numeric components, which
+// compare by mixed key.
+func compareComponent(a, b string) int {
+	an, bn := allDigits(a, isDigit), allDigits(b, isDigit)
+	switch {
+	case an && bn:
+		return compareNum(trimZeros(a), trimZeros(b))
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return compareMixed(ParseMixed(a), ParseMixed(b))
+}
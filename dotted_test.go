@@ -0,0 +1,70 @@
+package stringsort
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompareDotted(t *testing.T) {
+	tests := []struct {
+		a, b string
+		pad  DottedPad
+		want int
+	}{
+		{"", "", PadNone, 0},
+		{"10.2.1.100", "10.10.1.2", PadNone, -1},
+		{"10.2.1.100", "10.2.1.99", PadNone, 1},
+		{"1.2", "1.2.0", PadNone, -1},
+		{"1.2", "1.2.0", PadZero, -1}, // equal components, broken lexicographically
+		{"1.2.0", "1.2", PadZero, 1},
+		{"1.2", "1.2.1", PadZero, -1},
+		{"1.3", "1.2.1", PadZero, 1},
+		{"1.02", "1.2", PadNone, -1},
+		{"1.2", "1.02", PadNone, 1},
+		{"1.10", "1.x", PadNone, -1},
+		{"1.rc2", "1.rc10", PadNone, -1},
+		{"1.x", "1", PadZero, 1},
+		{"1.2", "1.02.0", PadNone, -1},
+		{"1.2", "1.02.0", PadZero, 1},
+		{"1..2", "1.0.2", PadNone, 1}, // empty components are text
+		{"99999999999999999999.1", "100000000000000000000.0", PadNone, -1},
+	}
+	for _, test := range tests {
+		if got := CompareDotted(test.a, test.b, test.pad); got != test.want {
+			t.Errorf("CompareDotted(%q, %q, %v): got %d, want %d", test.a, test.b, test.pad, got, test.want)
+		}
+	}
+}
+
+func TestByDotted(t *testing.T) {
+	tests := []struct {
+		pad  DottedPad
+		want []string // in the expected order
+	}{
+		{PadNone, []string{
+			"1", "1.0", "1.0.0", "1.2", "1.2.0", "1.2.9", "1.2.10", "1.10", "1.x",
+			"10.2.1.100", "10.10.1.2", "192.168.0.1", "192.168.0.10",
+		}},
+		{PadZero, []string{
+			"1", "1.0", "1.0.0", "1.2", "1.2.0", "1.2.9", "1.2.10", "1.10", "1.x",
+			"10.2.1.100", "10.10.1.2", "192.168.0.1", "192.168.0.10",
+		}},
+		{PadZero, []string{"1.0.1", "1.1", "1.1.0.0", "1.1.0.1", "2"}},
+		{PadNone, []string{"1.2", "1.02.0", "1.2.0.1"}},
+		{PadZero, []string{"1.02.0", "1.2", "1.2.0.1"}},
+	}
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			got := copyStrings(test.want)
+			rand.Shuffle(len(got), func(i, j int) { got[i], got[j] = got[j], got[i] })
+			sort.Sort(ByDotted(got, test.pad))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ByDotted(%v): (-want, +got):\n%s", test.pad, diff)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package stringsort
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface. The encoding
+// of a key is the concatenation of its spans, each encoded as the non-digit
+// run in Go quoted string syntax followed by the value in decimal, if any.
+// For example, the key for "alpha25bravo-3" is encoded as:
+//
+//	"alpha"25"bravo-"3
+//
+// and the key for "101 dalmatians" is encoded as:
+//
+//	""101" dalmatians"
+//
+// If the key records the original digits of a value (see Options.DigitOrder),
+// they follow the value after a "/", as in "echo"1/001. If a value has a unit
+// (see Options.Attached), it follows the value after an "@", quoted, as in
+// "load"50@"%". A file extension (see Options.SplitExtension) is encoded
+// with a "*" before its quoted text, as in "img"2*".png", and a span that
+// begins a pre-release (see Options.PreRelease) with a "~" before its run,
+// as in ""1~"-rc". An empty key is encoded as an empty string.
+func (k MixedKey) MarshalText() ([]byte, error) {
+	var buf []byte
+	for _, span := range k {
+		if span.ext {
+			buf = append(buf, '*')
+		} else if span.pre {
+			buf = append(buf, '~')
+		}
+		buf = strconv.AppendQuote(buf, span.run)
+		buf = append(buf, span.Value()...)
+		if span.digits != "" {
+			buf = append(buf, '/')
+			buf = append(buf, span.digits...)
+		}
+		if span.unit != "" {
+			buf = append(buf, '@')
+			buf = strconv.AppendQuote(buf, span.unit)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It
+// accepts the encoding produced by MarshalText.
+func (k *MixedKey) UnmarshalText(text []byte) error {
+	var out MixedKey
+	s := string(text)
+	for s != "" {
+		if strings.HasPrefix(s, "*") {
+			ext, rest, err := cutQuoted(s[1:])
+			if err != nil {
+				return fmt.Errorf("invalid extension at %q: %w", s, err)
+			}
+			out = append(out, Span{run: ext, ext: true})
+			s = rest
+			continue
+		}
+		pre := strings.HasPrefix(s, "~")
+		if pre {
+			s = s[1:]
+		}
+		run, rest, err := cutQuoted(s)
+		if err != nil {
+			return fmt.Errorf("invalid run at %q: %w", s, err)
+		}
+		s = rest
+
+		// The value extends to the start of the unit or the next span.
+		v := s
+		if i := strings.IndexAny(s, `"@*~`); i >= 0 {
+			v, s = s[:i], s[i:]
+		} else {
+			s = ""
+		}
+		span := Span{run: run}
+		if v != "" {
+			span, err = parseValue(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for run %q: %w", run, err)
+			}
+			span.run = run
+		}
+		span.pre = pre
+		if strings.HasPrefix(s, "@") {
+			if !span.hasNum {
+				return fmt.Errorf("unit without value for run %q", run)
+			} else if span.unit, s, err = cutQuoted(s[1:]); err != nil {
+				return fmt.Errorf("invalid unit for run %q: %w", run, err)
+			}
+		}
+		out = append(out, span)
+	}
+	*k = out
+	return nil
+}
+
+// cutQuoted unquotes the double-quoted string at the beginning of s, and
+// returns its contents along with the remainder of s.
+func cutQuoted(s string) (string, string, error) {
+	q, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", s, err
+	} else if q[0] != '"' {
+		return "", s, errors.New("missing quotation mark")
+	}
+	u, err := strconv.Unquote(q)
+	return u, s[len(q):], err
+}
+
+// parseValue parses the encoding of a value, [-]digits[.digits][/digits], as
+// produced by MarshalText, into a span without a run. The original digits
+// after the "/" may be hexadecimal (see Options.Hex).
+func parseValue(v string) (Span, error) {
+	span := Span{hasNum: true}
+	v, digits, hasDigits := strings.Cut(v, "/")
+	v, span.neg = strings.CutPrefix(v, "-")
+	num, frac, hasFrac := strings.Cut(v, ".")
+	if !allDigits(num, isDigit) || (hasFrac && !allDigits(frac, isDigit)) ||
+		(hasDigits && !allDigits(digits, isHexDigit)) {
+		return span, errors.New("malformed value")
+	}
+	span.digits = digits
+	span.num = trimZeros(num)
+	span.frac = strings.TrimRight(frac, "0")
+	span.neg = span.neg && !span.isZero()
+	return span, nil
+}
+
+// allDigits reports whether s is a non-empty string of bytes satisfying f.
+func allDigits(s string, f func(byte) bool) bool {
+	for i := 0; i < len(s); i++ {
+		if !f(s[i]) {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,113 @@
+package stringsort
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		key  MixedKey
+		want string
+	}{
+		{nil, ``},
+		{ParseMixed("foo"), `"foo"`},
+		{ParseMixed("x0"), `"x"0`},
+		{ParseMixed("alpha25bravo-3"), `"alpha"25"bravo-"3`},
+		{ParseMixed("101 dalmatians"), `""101" dalmatians"`},
+		{ParseMixed(`say "hi" 2`), `"say \"hi\" "2`},
+		{ParseMixedSigned("t-5"), `"t"-5`},
+		{ParseMixedFloat("v1.50"), `"v"1.5`},
+		{(&Options{DigitOrder: ShorterFirst}).Parse("echo001"), `"echo"1/001`},
+		{(&Options{Attached: "%"}).Parse("load50%"), `"load"50@"%"`},
+		{(&Options{SplitExtension: true}).Parse("img2.png"), `"img"2*".png"`},
+		{(&Options{PreRelease: "-"}).Parse("1-rc2"), `""1~"-rc"2`},
+	}
+	for _, test := range tests {
+		got, err := test.key.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText %v: unexpected error: %v", test.key, err)
+		} else if string(got) != test.want {
+			t.Errorf("MarshalText %v: got %#q, want %#q", test.key, got, test.want)
+		}
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	all := &Options{Signed: true, Float: true, Hex: true, DigitOrder: LongerFirst}
+	tests := []MixedKey{
+		nil,
+		ParseMixed("foo"),
+		ParseMixed("0"),
+		ParseMixed("007 bond"),
+		ParseMixed("alpha25bravo-3"),
+		ParseMixed("101 dalmatians"),
+		ParseMixed("1\t2\"3\\4\x00"),
+		ParseMixed("\xff\xfe5"),
+		ParseMixed("file999999999999999999999.png"),
+		all.Parse("t-0x1F-2.50 x0y-0"),
+		all.Parse("échelle 0.0"),
+		(&Options{Attached: "%$€", DigitOrder: ShorterFirst}).Parse("$5 is 10% of €050"),
+		(&Options{SplitExtension: true}).Parse("file2.tar.gz"),
+		(&Options{SplitExtension: true}).Parse("README.md"),
+		(&Options{PreRelease: "-"}).Parse("v1.0-rc.1"),
+		(&Options{PreRelease: "-"}).Parse("2-beta"),
+	}
+	opt := cmp.AllowUnexported(Span{})
+	for _, key := range tests {
+		text, err := key.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText %v: unexpected error: %v", key, err)
+		}
+		var got MixedKey
+		if err := got.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%#q): unexpected error: %v", text, err)
+		} else if diff := cmp.Diff(key, got, opt); diff != "" {
+			t.Errorf("UnmarshalText(%#q): (-want, +got):\n%s", text, diff)
+		}
+	}
+}
+
+func TestTextJSON(t *testing.T) {
+	key := ParseMixed("alpha25bravo-3")
+	bits, err := json.Marshal(map[string]MixedKey{"key": key})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got map[string]MixedKey
+	if err := json.Unmarshal(bits, &got); err != nil {
+		t.Fatalf("Unmarshal %s failed: %v", bits, err)
+	}
+	if diff := cmp.Diff(key, got["key"], cmp.AllowUnexported(Span{})); diff != "" {
+		t.Errorf("JSON round trip: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalTextErrors(t *testing.T) {
+	tests := []string{
+		`foo`,
+		`"foo`,
+		`'foo'0`,
+		`"foo"x`,
+		`"foo"1.`,
+		`"foo".5`,
+		`"foo"--1`,
+		`"foo"1/`,
+		`"foo"1/x`,
+		`"foo"1"bar`,
+		`"foo"@"%"`,
+		`"foo"1@`,
+		`"foo"1@%`,
+		`"foo"1@"%`,
+		`*`,
+		`*".png"1`,
+	}
+	for _, test := range tests {
+		var got MixedKey
+		if err := got.UnmarshalText([]byte(test)); err == nil {
+			t.Errorf("UnmarshalText(%#q): got %v, want error", test, got)
+		}
+	}
+}
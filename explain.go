@@ -0,0 +1,143 @@
+package stringsort
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explain returns a human-readable account of why Compare orders a and b as
+// it does, for example:
+//
+//	"file2" < "file10": numeric 2 < 10 at span 0
+//	"alpha1" < "beta1": text run "alpha" < "beta" at span 0
+//	"x01" < "x1": keys are equal; tie broken by lexicographic order
+//
+// The explanation reports the first span at which the keys of a and b differ,
+// and which part of the span decided the order. Spans are numbered from 0.
+// The order reported is always the order Compare returns.
+func Explain(a, b string) string { return (*Options)(nil).Explain(a, b) }
+
+// Explain returns a human-readable account of why o.Compare orders a and b as
+// it does, as described for the Explain function.
+func (o *Options) Explain(a, b string) string {
+	head := fmt.Sprintf("%q %s %q: ", a, relation(o.Compare(a, b)), b)
+	if a == b {
+		return head + "strings are identical"
+	}
+	if why := o.explainKeys(o.Parse(a), o.Parse(b)); why != "" {
+		if o.descending() {
+			why += " (descending)"
+		}
+		return head + why
+	}
+	if tie := o.tieBreak(); tie != nil && tie(a, b) != 0 {
+		return head + "keys are equal; tie broken by TieBreak"
+	} else if c := o.collator(); c != nil && c.CompareString(a, b) != 0 {
+		return head + "keys are equal; tie broken by collator"
+	}
+	return head + "keys are equal; tie broken by lexicographic order"
+}
+
+// explainKeys describes the first difference between keys a and b, using the
+// same comparisons as o.compareMixed. It returns "" if the keys are equal.
+func (o *Options) explainKeys(a, b MixedKey) string {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := a[i], b[i]
+		c := o.compareSpan(x, y)
+		if c == 0 {
+			continue
+		}
+		rel := relation(c)
+		switch {
+		case x.pre != y.pre, x.ext != y.ext:
+			return fmt.Sprintf("%s %s %s at span %d", describeRun(x), rel, describeRun(y), i)
+		case o.compareRun(x.run, y.run) != 0:
+			return fmt.Sprintf("%s %s %q at span %d", describeRun(x), rel, y.run, i)
+		case x.unit != y.unit:
+			return fmt.Sprintf("unit %q %s %q at span %d", x.unit, rel, y.unit, i)
+		case o.compareValues(x, y) != 0:
+			return o.explainValues(x, y, i)
+		default:
+			return fmt.Sprintf("digits %q %s %q at span %d", x.digits, rel, y.digits, i)
+		}
+	}
+	if n := min(len(a), len(b)); len(a) > n && a[n].pre {
+		return fmt.Sprintf("%s < release at span %d", describeRun(a[n]), n)
+	} else if len(b) > n && b[n].pre {
+		return fmt.Sprintf("release > %s at span %d", describeRun(b[n]), n)
+	}
+	if len(a) != len(b) {
+		return fmt.Sprintf("span count %d %s %d", len(a), relation(compareInt(len(a), len(b))), len(b))
+	}
+	return ""
+}
+
+// explainValues describes the difference between the values of spans a and
+// b at index i, whose runs and units are equal, using the same comparisons
+// as o.compareValues.
+func (o *Options) explainValues(a, b Span, i int) string {
+	if a.hasNum != b.hasNum {
+		rel := relation(o.compareSpan(a, b))
+		return fmt.Sprintf("numeric %s %s %s at span %d", describeValue(a), rel, describeValue(b), i)
+	}
+	rel, note := relation(o.compareValues(a, b)), ""
+	if o.valuesDescending() {
+		note = " (values descending)"
+	}
+	if o.lengthFirst() && a.neg == b.neg && len(a.digits) != len(b.digits) {
+		if a.neg {
+			note = " (negative)" + note
+		}
+		return fmt.Sprintf("digits %q %s %q by length at span %d%s", a.digits, rel, b.digits, i, note)
+	}
+	if w := o.fixedWidth(); w > 0 && a.neg == b.neg {
+		la, lb := len(a.digits), len(b.digits)
+		if (la <= w) != (lb <= w) || (la <= w && la != lb) {
+			if a.neg {
+				note = " (negative)" + note
+			}
+			return fmt.Sprintf("field %q %s %q of width %d at span %d%s",
+				describeField(a.digits, w), rel, describeField(b.digits, w), w, i, note)
+		}
+	}
+	return fmt.Sprintf("numeric %s %s %s at span %d%s", a.Value(), rel, b.Value(), i, note)
+}
+
+// describeRun describes the non-digit run of n for Explain.
+func describeRun(n Span) string {
+	if n.ext {
+		return fmt.Sprintf("extension %q", n.run)
+	} else if n.pre {
+		return fmt.Sprintf("pre-release %q", n.run)
+	}
+	return fmt.Sprintf("text run %q", n.run)
+}
+
+// describeField describes the digits of a value as a field of width w for
+// Explain, right-aligned and padded with spaces if they fit.
+func describeField(digits string, w int) string {
+	if len(digits) < w {
+		return strings.Repeat(" ", w-len(digits)) + digits
+	}
+	return digits
+}
+
+// describeValue describes the value of n for Explain.
+func describeValue(n Span) string {
+	if !n.hasNum {
+		return "(none)"
+	}
+	return n.Value()
+}
+
+// relation returns the comparison operator corresponding to the result c of
+// a three-way comparison.
+func relation(c int) string {
+	switch {
+	case c < 0:
+		return "<"
+	case c > 0:
+		return ">"
+	}
+	return "=="
+}
@@ -0,0 +1,65 @@
+package stringsort
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		opts *Options
+		a, b string
+		want string
+	}{
+		{nil, "x", "x", `"x" == "x": strings are identical`},
+		{nil, "file2", "file10", `"file2" < "file10": numeric 2 < 10 at span 0`},
+		{nil, "a1alpha2", "a1beta1", `"a1alpha2" < "a1beta1": text run "alpha" < "beta" at span 1`},
+		{nil, "abd", "abc", `"abd" > "abc": text run "abd" > "abc" at span 0`},
+		{nil, "item", "item1", `"item" < "item1": numeric (none) < 1 at span 0`},
+		{nil, "", "a", `"" < "a": span count 0 < 1`},
+		{nil, "x01", "x1", `"x01" < "x1": keys are equal; tie broken by lexicographic order`},
+		{&Options{ValuesFirst: true}, "item", "item1", `"item" > "item1": numeric (none) > 1 at span 0`},
+		{&Options{Descending: true}, "file2", "file10", `"file2" > "file10": numeric 2 < 10 at span 0 (descending)`},
+		{&Options{Signed: true}, "t-5", "t-10", `"t-5" > "t-10": numeric -5 > -10 at span 0`},
+		{&Options{LengthFirst: true}, "id01", "id1", `"id01" > "id1": digits "01" > "1" by length at span 0`},
+		{&Options{LengthFirst: true}, "id999", "id0001", `"id999" < "id0001": digits "999" < "0001" by length at span 0`},
+		{&Options{LengthFirst: true}, "id02", "id10", `"id02" < "id10": numeric 2 < 10 at span 0`},
+		{&Options{LengthFirst: true, Signed: true}, "t-01", "t-5", `"t-01" < "t-5": digits "01" < "5" by length at span 0 (negative)`},
+		{&Options{FixedWidth: 3}, "A07", "A7", `"A07" > "A7": field " 07" > "  7" of width 3 at span 0`},
+		{&Options{FixedWidth: 3}, "A99", "A007", `"A99" < "A007": field " 99" < "007" of width 3 at span 0`},
+		{&Options{FixedWidth: 3}, "A1000", "A999", `"A1000" > "A999": field "1000" > "999" of width 3 at span 0`},
+		{&Options{FixedWidth: 3}, "A07", "A09", `"A07" < "A09": numeric 7 < 9 at span 0`},
+		{&Options{FixedWidth: 3}, "A1000", "A01001", `"A1000" < "A01001": numeric 1000 < 1001 at span 0`},
+		{&Options{ValuesDescending: true}, "x1", "x2", `"x1" > "x2": numeric 1 < 2 at span 0 (values descending)`},
+		{&Options{ValuesDescending: true, LengthFirst: true}, "x01", "x1", `"x01" < "x1": digits "01" > "1" by length at span 0 (values descending)`},
+		{&Options{Attached: "$€"}, "cost€3", "cost$100", `"cost€3" > "cost$100": unit "€" > "$" at span 0`},
+		{&Options{DigitOrder: ShorterFirst}, "x01", "x1", `"x01" > "x1": digits "01" > "1" at span 0`},
+		{&Options{SplitExtension: true}, "img10.png", "img10a.gif", `"img10.png" < "img10a.gif": extension ".png" < text run "a" at span 1`},
+		{&Options{SplitExtension: true}, "a.png", "a.gif", `"a.png" > "a.gif": extension ".png" > ".gif" at span 1`},
+		{&Options{PreRelease: "-"}, "1.0-rc", "1.0", `"1.0-rc" < "1.0": pre-release "-rc" < release at span 2`},
+		{&Options{PreRelease: "-"}, "1.0", "1.0-rc", `"1.0" > "1.0-rc": release > pre-release "-rc" at span 2`},
+		{&Options{PreRelease: "-"}, "1.0-rc", "1.0.1", `"1.0-rc" < "1.0.1": pre-release "-rc" < text run "." at span 2`},
+		{&Options{FoldCase: true}, "A1", "a1", `"A1" < "a1": keys are equal; tie broken by lexicographic order`},
+		{&Options{FoldCase: true, TieBreak: func(a, b string) int { return strings.Compare(b, a) }}, "A1", "a1",
+			`"A1" > "a1": keys are equal; tie broken by TieBreak`},
+	}
+	for _, test := range tests {
+		if got := test.opts.Explain(test.a, test.b); got != test.want {
+			t.Errorf("Explain(%q, %q):\n got %s\nwant %s", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestExplainMatchesCompare(t *testing.T) {
+	input := benchInput(50)
+	input = append(input, "", "a", "file", "file01-part1.txt", "file1-part1.txt")
+	for _, a := range input {
+		for _, b := range input {
+			want := relation(Compare(a, b))
+			got := Explain(a, b)
+			if !strings.HasPrefix(got, `"`+a+`" `+want+` "`+b+`": `) {
+				t.Errorf("Explain(%q, %q): got %s, want relation %s", a, b, got, want)
+			}
+		}
+	}
+}
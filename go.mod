@@ -1,5 +1,8 @@
 module github.com/creachadair/stringsort
 
-go 1.20
+go 1.23
 
-require github.com/google/go-cmp v0.6.0
+require (
+	github.com/google/go-cmp v0.6.0
+	golang.org/x/text v0.14.0
+)
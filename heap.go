@@ -0,0 +1,37 @@
+package stringsort
+
+// MixedHeap is a min-heap of strings ordered by mixed key, for use with the
+// container/heap package. The root, h[0], is the smallest string by mixed
+// key, with ties broken as for Compare. For example:
+//
+//	h := &stringsort.MixedHeap{"task10", "task2"}
+//	heap.Init(h)
+//	heap.Push(h, "task1")
+//	next := heap.Pop(h).(string) // "task1"
+//
+// MixedHeap does not cache keys, so each comparison parses the strings being
+// compared, as Compare does.
+type MixedHeap []string
+
+// Len implements part of heap.Interface.
+func (h MixedHeap) Len() int { return len(h) }
+
+// Less implements part of heap.Interface. It reports whether h[i] < h[j] by
+// mixed key.
+func (h MixedHeap) Less(i, j int) bool { return Compare(h[i], h[j]) < 0 }
+
+// Swap implements part of heap.Interface.
+func (h MixedHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push implements part of heap.Interface. It adds x, which must be a string,
+// to the end of h. Use heap.Push to add a string to the heap.
+func (h *MixedHeap) Push(x any) { *h = append(*h, x.(string)) }
+
+// Pop implements part of heap.Interface. It removes and returns the last
+// element of h. Use heap.Pop to remove the smallest string from the heap.
+func (h *MixedHeap) Pop() any {
+	n := len(*h) - 1
+	s := (*h)[n]
+	*h = (*h)[:n]
+	return s
+}
@@ -0,0 +1,25 @@
+package stringsort
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMixedHeap(t *testing.T) {
+	h := &MixedHeap{"task10", "task2", "task01"}
+	heap.Init(h)
+	for _, s := range []string{"task1", "alpha", "task3", "task001"} {
+		heap.Push(h, s)
+	}
+
+	var got []string
+	for h.Len() != 0 {
+		got = append(got, heap.Pop(h).(string))
+	}
+	want := []string{"alpha", "task001", "task01", "task1", "task2", "task3", "task10"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MixedHeap: (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,56 @@
+package stringsort
+
+import "sort"
+
+// A Keyer caches the mixed keys for a slice of strings, so that the strings
+// can be sorted repeatedly without parsing them again.
+//
+// The Keyer does not copy the slice it is given. The caller must not modify
+// the strings in the slice while the Keyer is in use, except by sorting it
+// with the sorter returned by the ByMixedKey method, which keeps the cached
+// keys in correspondence with the strings.
+type Keyer struct {
+	ss   []string
+	keys []MixedKey
+}
+
+// NewKeyer constructs a Keyer that caches the mixed keys of ss.
+func NewKeyer(ss []string) *Keyer {
+	kp := (*Options)(nil).byMixedKey(ss)
+	return &Keyer{ss: kp.ss, keys: kp.keys}
+}
+
+// Len reports the number of strings in k.
+func (k *Keyer) Len() int { return len(k.ss) }
+
+// Key returns the cached mixed key for the string at offset i.
+func (k *Keyer) Key(i int) MixedKey { return k.keys[i] }
+
+// ByMixedKey returns a sorter that orders the strings of k in place,
+// non-decreasing by mixed key. Ties are broken as for the ByMixedKey function.
+func (k *Keyer) ByMixedKey() sort.Interface { return byMixedKey{ss: k.ss, keys: k.keys} }
+
+// ByIndex returns a sorter that orders idx, whose elements are offsets into
+// the strings of k, non-decreasing by the mixed keys of the corresponding
+// strings. The strings of k are not modified. Ties are broken as for the
+// ByMixedKey function.
+//
+// This is useful to order a subset of the strings, for example to sort the
+// results of a filter without reparsing. ByIndex will panic if idx contains
+// an offset out of range for k.
+func (k *Keyer) ByIndex(idx []int) sort.Interface { return byIndex{k: k, idx: idx} }
+
+// byIndex implements sort.Interface over a slice of offsets into a Keyer.
+type byIndex struct {
+	k   *Keyer
+	idx []int
+}
+
+func (b byIndex) Len() int { return len(b.idx) }
+
+func (b byIndex) Less(i, j int) bool {
+	p, q := b.idx[i], b.idx[j]
+	return (*Options)(nil).compareStrings(b.k.ss[p], b.k.ss[q], b.k.keys[p], b.k.keys[q]) < 0
+}
+
+func (b byIndex) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
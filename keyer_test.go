@@ -0,0 +1,80 @@
+package stringsort
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKeyer(t *testing.T) {
+	input := []string{"b10", "a2", "b1", "a10", "b01", "a1"}
+	k := NewKeyer(input)
+	if k.Len() != len(input) {
+		t.Errorf("Len: got %d, want %d", k.Len(), len(input))
+	}
+
+	// Sorting a subset of indices does not disturb the strings.
+	idx := []int{0, 2, 4, 1}
+	sort.Sort(k.ByIndex(idx))
+	if diff := cmp.Diff([]int{1, 4, 2, 0}, idx); diff != "" {
+		t.Errorf("ByIndex: (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"b10", "a2", "b1", "a10", "b01", "a1"}, input); diff != "" {
+		t.Errorf("ByIndex modified input: (-want, +got):\n%s", diff)
+	}
+
+	// Sorting the whole slice keeps the keys in correspondence.
+	sort.Sort(k.ByMixedKey())
+	if diff := cmp.Diff([]string{"a1", "a2", "a10", "b01", "b1", "b10"}, input); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+	opt := cmp.AllowUnexported(Span{})
+	for i, s := range input {
+		if diff := cmp.Diff(ParseMixed(s), k.Key(i), opt); diff != "" {
+			t.Errorf("Key(%d) for %q: (-want, +got):\n%s", i, s, diff)
+		}
+	}
+}
+
+// benchInput returns n pseudo-random strings with embedded digit runs.
+func benchInput(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = fmt.Sprintf("file-%d-part%d.txt", r.Intn(1000), r.Intn(100))
+	}
+	return ss
+}
+
+func BenchmarkRepeatedSort(b *testing.B) {
+	input := benchInput(10000)
+	idx := make([]int, 0, len(input))
+
+	b.Run("ByMixedKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sub := make([]string, 0, len(input))
+			for j, s := range input {
+				if j%3 == i%3 {
+					sub = append(sub, s)
+				}
+			}
+			sort.Sort(ByMixedKey(sub))
+		}
+	})
+	b.Run("Keyer", func(b *testing.B) {
+		k := NewKeyer(input)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			idx = idx[:0]
+			for j := range input {
+				if j%3 == i%3 {
+					idx = append(idx, j)
+				}
+			}
+			sort.Sort(k.ByIndex(idx))
+		}
+	})
+}
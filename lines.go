@@ -0,0 +1,133 @@
+package stringsort
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"io"
+	"strings"
+)
+
+// SortLines reads newline-terminated lines from r, sorts them by mixed key as
+// by Strings, and writes them to w, each terminated by a newline.
+//
+// The last line of the input need not end with a newline; in the output,
+// every line does. Blank lines are preserved, and sort before all non-blank
+// lines. Lines are compared without their trailing newlines, but any other
+// content, including a carriage return, is part of the line.
+func SortLines(r io.Reader, w io.Writer) error {
+	lines, err := readLines(r)
+	if err != nil {
+		return err
+	}
+	Strings(lines)
+	return writeLines(w, lines)
+}
+
+// MergeReaders reads newline-terminated lines from each of rs, each of which
+// must already be sorted by mixed key in the order produced by SortLines, and
+// writes the lines of all the inputs to w in the same order, each terminated
+// by a newline. Lines are read, compared, and written as by SortLines, so the
+// output is the same as sorting the concatenation of the inputs.
+//
+// MergeReaders reads each input incrementally, and holds only one line of each
+// input in memory at a time. If an input is not sorted, the order of the
+// output is unspecified. If reading an input fails, MergeReaders stops and
+// returns the error, and some of the output may already have been written.
+func MergeReaders(w io.Writer, rs ...io.Reader) error {
+	bw := bufio.NewWriter(w)
+	h := make(lineHeap, 0, len(rs))
+	for _, r := range rs {
+		in := &lineInput{br: bufio.NewReader(r)}
+		if ok, err := in.next(); err != nil {
+			return err
+		} else if ok {
+			h = append(h, in)
+		}
+	}
+	heap.Init(&h)
+	for len(h) != 0 {
+		in := h[0]
+		bw.WriteString(in.line)
+		bw.WriteByte('\n')
+		if ok, err := in.next(); err != nil {
+			bw.Flush()
+			return err
+		} else if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return bw.Flush()
+}
+
+// lineInput is an input to MergeReaders, with its current line and the mixed
+// key of that line.
+type lineInput struct {
+	br   *bufio.Reader
+	line string
+	key  MixedKey
+}
+
+// next reads the next line of the input, and reports whether there was one.
+func (in *lineInput) next() (bool, error) {
+	line, err := in.br.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	} else if line == "" {
+		return false, nil // end of input
+	}
+	in.line = strings.TrimSuffix(line, "\n")
+	in.key = ParseMixedInto(in.line, in.key)
+	return true, nil
+}
+
+// lineHeap implements heap.Interface for inputs to MergeReaders, ordered by
+// the current line of each input.
+type lineHeap []*lineInput
+
+func (h lineHeap) Len() int { return len(h) }
+
+func (h lineHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	return (*Options)(nil).compareStrings(a.line, b.line, a.key, b.key) < 0
+}
+
+func (h lineHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *lineHeap) Push(x any) { *h = append(*h, x.(*lineInput)) }
+
+func (h *lineHeap) Pop() any {
+	n := len(*h) - 1
+	in := (*h)[n]
+	*h = (*h)[:n]
+	return in
+}
+
+// readLines reads all the lines of r, without their trailing newlines.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// writeLines writes each of lines to w, followed by a newline.
+func writeLines(w io.Writer, lines []string) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		bw.WriteString(line)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,101 @@
+package stringsort
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSortLines(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{"\n", "\n"},
+		{"x", "x\n"},
+		{"x\n", "x\n"},
+		{"file10\nfile2\nfile1", "file1\nfile2\nfile10\n"},
+		{"file10\n\nfile2\n\n", "\n\nfile2\nfile10\n"},
+		{"b 3\r\nb 20\r\na\r\n", "a\r\nb 3\r\nb 20\r\n"},
+	}
+	for _, test := range tests {
+		var out strings.Builder
+		if err := SortLines(strings.NewReader(test.input), &out); err != nil {
+			t.Errorf("SortLines(%q): unexpected error: %v", test.input, err)
+		} else if got := out.String(); got != test.want {
+			t.Errorf("SortLines(%q): got %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+type errReader struct{ err error }
+
+func (e errReader) Read([]byte) (int, error) { return 0, e.err }
+
+func TestSortLinesError(t *testing.T) {
+	want := errors.New("bad input")
+	r := io.MultiReader(strings.NewReader("a\nb\n"), errReader{want})
+	var out strings.Builder
+	if err := SortLines(r, &out); !errors.Is(err, want) {
+		t.Errorf("SortLines: got error %v, want %v", err, want)
+	}
+	if out.Len() != 0 {
+		t.Errorf("SortLines: unexpected output %q", out.String())
+	}
+}
+
+func TestMergeReaders(t *testing.T) {
+	tests := []struct {
+		inputs []string
+		want   string
+	}{
+		{nil, ""},
+		{[]string{"", ""}, ""},
+		{[]string{"file1\nfile10\n"}, "file1\nfile10\n"},
+		{[]string{"file1\nfile10", "file2\nfile20"}, "file1\nfile2\nfile10\nfile20\n"},
+		{[]string{"\nb2\n", "\n\na10", "a1\n"}, "\n\n\na1\na10\nb2\n"},
+		{[]string{"x01\nx2\n", "x1\nx02"}, "x01\nx1\nx02\nx2\n"},
+		{[]string{"b 3\r\nb 20\r\n", "a\r\n"}, "a\r\nb 3\r\nb 20\r\n"},
+	}
+	for _, test := range tests {
+		var rs []io.Reader
+		for _, in := range test.inputs {
+			rs = append(rs, strings.NewReader(in))
+		}
+		var out strings.Builder
+		if err := MergeReaders(&out, rs...); err != nil {
+			t.Errorf("MergeReaders(%q): unexpected error: %v", test.inputs, err)
+		} else if got := out.String(); got != test.want {
+			t.Errorf("MergeReaders(%q): got %q, want %q", test.inputs, got, test.want)
+		}
+
+		// The result is the same as sorting the concatenated inputs.
+		var all strings.Builder
+		for _, in := range test.inputs {
+			all.WriteString(in)
+			if in != "" && !strings.HasSuffix(in, "\n") {
+				all.WriteByte('\n')
+			}
+		}
+		var sorted strings.Builder
+		if err := SortLines(strings.NewReader(all.String()), &sorted); err != nil {
+			t.Fatalf("SortLines: unexpected error: %v", err)
+		} else if sorted.String() != test.want {
+			t.Errorf("SortLines(%q): got %q, want %q", all.String(), sorted.String(), test.want)
+		}
+	}
+}
+
+func TestMergeReadersError(t *testing.T) {
+	want := errors.New("bad input")
+	for _, rs := range [][]io.Reader{
+		{strings.NewReader("a\n"), errReader{want}},
+		{strings.NewReader("a\n"), io.MultiReader(strings.NewReader("b\n"), errReader{want})},
+	} {
+		var out strings.Builder
+		if err := MergeReaders(&out, rs...); !errors.Is(err, want) {
+			t.Errorf("MergeReaders: got error %v, want %v", err, want)
+		}
+	}
+}
@@ -21,9 +21,41 @@
 // these keys will preserve the intuitive ordering of digit sequences.
 //
 // This approach emulates the ordering used by the macOS Finder for file names.
+//
+// # Invalid UTF-8
+//
+// Mixed keys are defined for all strings, including strings that are not
+// valid UTF-8. By default strings are scanned by bytes, and since ASCII
+// digits never occur within the UTF-8 encoding of another rune, runs are
+// split only at rune boundaries: The runs of a valid string are valid, and
+// the bytes of an invalid encoding are kept in the non-digit run in which
+// they occur, and compared as bytes. Options that scan by runes, such as
+// Options.UnicodeDigits and Options.DigitFunc, treat each invalid byte as a
+// non-digit rune of its own. However, options that change the text of runs,
+// such as Options.FoldCase and Options.Normalize, may replace invalid bytes
+// with U+FFFD, so that distinct invalid strings can have equal keys.
+//
+// To reject invalid input rather than sorting it, use ParseMixedStrict or
+// Options.ParseStrict, which report an error for a string that is not valid
+// UTF-8.
 package stringsort
 
-import "sort"
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
 
 // ByMixedKey returns a sorter that orders ss non-decreasing by mixed key. The
 // keys are precomputed at the point of construction.
@@ -31,32 +63,57 @@ import "sort"
 // Note that non-identical strings may have equal mixed keys, consider for
 // example "xyzzy1" and "xyzzy01". To ensure a deterministic order, ties on key
 // order are broken using the lexicgraphic order of the original strings.
-func ByMixedKey(ss []string) sort.Interface {
-	kp := byMixedKey{
-		ss:   ss,
-		keys: make([]MixedKey, len(ss)),
-	}
-	for i, s := range ss {
-		kp.keys[i] = ParseMixed(s)
-	}
-	return kp
+//
+// The resulting order is deterministic by value: It depends only on the
+// strings, and not on their original positions in ss. To preserve the input
+// order of strings with equal keys, use StableByMixedKey.
+//
+// ByMixedKey orders ss as by ByComparer(ss, DefaultComparer()).
+func ByMixedKey(ss []string) sort.Interface { return ByComparer(ss, DefaultComparer()) }
+
+// StableByMixedKey returns a sorter that orders ss non-decreasing by mixed
+// key, for use with sort.Stable. Unlike ByMixedKey, ties on key order are not
+// broken, so strings whose keys are equal, such as "xyzzy1" and "xyzzy01",
+// remain in their original relative order:
+//
+//	sort.Stable(StableByMixedKey(ss))
+//
+// This order is stable by position: It depends on the original positions of
+// the strings in ss, as well as their values. Use it when the position of a
+// string in the input is significant, for example to keep the first of
+// several equivalent entries first. Sorting with sort.Sort instead of
+// sort.Stable does not preserve the order of equal elements.
+func StableByMixedKey(ss []string) sort.Interface {
+	return stableByMixedKey{(*Options)(nil).byMixedKey(ss)}
+}
+
+// stableByMixedKey implements sort.Interface using mixed keys only, without
+// breaking ties, for use with sort.Stable.
+type stableByMixedKey struct{ byMixedKey }
+
+func (b stableByMixedKey) Less(i, j int) bool {
+	return b.opts.compareMixed(b.keys[i], b.keys[j]) < 0
+}
+
+// ByMixedKeyFold returns a sorter that orders ss non-decreasing by mixed key,
+// with the case of non-digit runs folded as described for Options.FoldCase.
+// Ties on key order are broken using the lexicographic order of the original
+// strings, without case folding.
+func ByMixedKeyFold(ss []string) sort.Interface {
+	return (&Options{FoldCase: true}).ByMixedKey(ss)
 }
 
 // byMixedKey implements sort.Interface using mixed keys.
 type byMixedKey struct {
 	ss   []string   // the original slice to be sorted
 	keys []MixedKey // keys corresponding to ss
+	opts *Options   // options for comparison (may be nil)
 }
 
 func (b byMixedKey) Len() int { return len(b.ss) }
 
 func (b byMixedKey) Less(i, j int) bool {
-	v := compareMixed(b.keys[i], b.keys[j])
-	if v == 0 {
-		// Break ties using lexicographic order, to ensure deterministic output.
-		return b.ss[i] < b.ss[j]
-	}
-	return v < 0
+	return b.opts.compareStrings(b.ss[i], b.ss[j], b.keys[i], b.keys[j]) < 0
 }
 
 func (b byMixedKey) Swap(i, j int) {
@@ -64,9 +121,528 @@ func (b byMixedKey) Swap(i, j int) {
 	b.keys[i], b.keys[j] = b.keys[j], b.keys[i] // update their keys
 }
 
+// ByMixedKeyValuesDesc returns a sorter that orders ss by mixed key, with
+// non-digit runs ascending but values descending, as described for
+// Options.ValuesDescending. For example,
+//
+//	buildA10 buildA2 buildA1 buildB buildB3
+//
+// are in order. Ties on key order are broken using the lexicographic order of
+// the original strings.
+func ByMixedKeyValuesDesc(ss []string) sort.Interface {
+	return (&Options{ValuesDescending: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyCaseTie returns a sorter that orders ss non-decreasing by mixed
+// key with the case of non-digit runs folded, as described for
+// Options.FoldCase, and breaks ties between strings that differ only in case
+// by a fixed case order. If upperFirst is true, the first letter that differs
+// between two such strings is ordered upper case first, so that
+//
+//	A1 a1 A2 a2 a10
+//
+// are in order; otherwise lower case letters sort first. Remaining ties are
+// broken using the lexicographic order of the original strings.
+func ByMixedKeyCaseTie(ss []string, upperFirst bool) sort.Interface {
+	return (&Options{FoldCase: true, TieBreak: caseTie(upperFirst)}).ByMixedKey(ss)
+}
+
+// caseTie returns a tie-breaking function that compares a and b at the first
+// rune where they differ. If those runes differ only in case, they are
+// ordered upper case first if upperFirst is true, or lower case first
+// otherwise. If not, the function returns 0.
+func caseTie(upperFirst bool) func(a, b string) int {
+	return func(a, b string) int {
+		for a != "" && b != "" {
+			ra, na := utf8.DecodeRuneInString(a)
+			rb, nb := utf8.DecodeRuneInString(b)
+			if ra != rb {
+				if foldRune(ra) != foldRune(rb) {
+					return 0
+				} else if unicode.IsUpper(ra) == upperFirst {
+					return -1
+				}
+				return 1
+			}
+			a, b = a[na:], b[nb:]
+		}
+		return 0
+	}
+}
+
+// ByMixedKeyFirstValue returns a sorter that orders ss non-decreasing by
+// mixed key, where strings with values are ordered primarily by their first
+// value, as described for Options.FirstValue. This suits track-numbered lists
+// such as playlists. For example,
+//
+//	01 - Intro
+//	Bonus 2 - Remix
+//	03 - Finale
+//	12 - Song Title
+//	Outro
+//
+// are in order. Strings without values follow, in their usual order. Ties on
+// key order are broken using the lexicographic order of the original strings.
+func ByMixedKeyFirstValue(ss []string) sort.Interface {
+	return (&Options{FirstValue: true}).ByMixedKey(ss)
+}
+
+// ByNumericKey returns a sorter that orders ss non-decreasing by the sequence
+// of values in their mixed keys, ignoring the non-digit runs, so that strings
+// are compared first by their first values, then by their second values, and
+// so on. A string with fewer values than another whose values begin with the
+// same values is less, so a string with no values sorts first. Ties are
+// broken using the lexicographic order of the original strings. For example,
+//
+//	none A2 A12B X12Y B12C3
+//
+// are in order.
+func ByNumericKey(ss []string) sort.Interface {
+	keys := make([]MixedKey, len(ss))
+	for i, s := range ss {
+		keys[i] = numericKey(ParseMixed(s))
+	}
+	return byMixedKey{ss: ss, keys: keys}
+}
+
+// numericKey returns the spans of key that have values, without their
+// non-digit runs. It modifies the contents of key.
+func numericKey(key MixedKey) MixedKey {
+	out := key[:0]
+	for _, sp := range key {
+		if sp.hasNum {
+			sp.run = ""
+			out = append(out, sp)
+		}
+	}
+	return out
+}
+
+// ByMixedKeyNumberFirst returns a sorter that orders ss non-decreasing by
+// mixed key, where strings with exactly one value are ordered primarily by
+// that value, as described for Options.NumberFirst. For example,
+//
+//	XYZ-00007-B ABC-00042-X ABC-00042-Y AB-100
+//
+// are in order. Ties on key order are broken using the lexicographic order of
+// the original strings.
+func ByMixedKeyNumberFirst(ss []string) sort.Interface {
+	return (&Options{NumberFirst: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyNormalized returns a sorter that orders ss non-decreasing by mixed
+// key, with runs of whitespace in the non-digit runs collapsed to a single
+// space as described for Options.CollapseSpace. Ties on key order are broken
+// using the lexicographic order of the original strings.
+func ByMixedKeyNormalized(ss []string) sort.Interface {
+	return (&Options{CollapseSpace: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyNFC returns a sorter that orders ss non-decreasing by the mixed
+// keys of the strings converted to Unicode normalization form NFC, as
+// described for Options.Normalize, so that canonically equivalent strings
+// have equal keys. Ties on key order are broken using the lexicographic order
+// of the original strings.
+func ByMixedKeyNFC(ss []string) sort.Interface {
+	return (&Options{Normalize: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyAccents returns a sorter that orders ss non-decreasing by mixed
+// key, with diacritical marks removed from the non-digit runs as described
+// for Options.FoldAccents. Ties on key order are broken using the
+// lexicographic order of the original strings.
+func ByMixedKeyAccents(ss []string) sort.Interface {
+	return (&Options{FoldAccents: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyCollator returns a sorter that orders ss non-decreasing by mixed
+// key, using c to compare the non-digit runs as described for
+// Options.Collator. Ties on key order are broken using c to compare the
+// original strings, and then by their lexicographic order.
+func ByMixedKeyCollator(ss []string, c *collate.Collator) sort.Interface {
+	return (&Options{Collator: c}).ByMixedKey(ss)
+}
+
+// ByMixedKeyRoot returns a sorter that orders ss non-decreasing by mixed key,
+// using the root collation order of the Unicode Collation Algorithm, without
+// language-specific tailoring, to compare the non-digit runs. In this order,
+// punctuation and symbols precede letters, and letters are ordered
+// alphabetically, with differences of accent and then case distinguishing
+// runs that are otherwise equal. For example,
+//
+//	_a1 (a)1 a1 A1 ä1 b1 Z1
+//
+// are in order. Values are still compared numerically. To use a tailored
+// order or different collation options, use ByMixedKeyCollator.
+func ByMixedKeyRoot(ss []string) sort.Interface {
+	// N.B. A collator is not safe for concurrent use, so each sorter needs
+	// its own.
+	return ByMixedKeyCollator(ss, collate.New(language.Und))
+}
+
+// ByMixedKeyTie returns a sorter that orders ss non-decreasing by mixed key,
+// using tie to order strings whose keys are equal, as described for
+// Options.TieBreak. If tie == nil, ties are broken using the lexicographic
+// order of the strings, as in ByMixedKey.
+//
+// For example, to order strings with equal keys by length:
+//
+//	ByMixedKeyTie(ss, func(a, b string) int { return len(a) - len(b) })
+func ByMixedKeyTie(ss []string, tie func(a, b string) int) sort.Interface {
+	return (&Options{TieBreak: tie}).ByMixedKey(ss)
+}
+
+// ByMixedKeyDesc returns a sorter that orders ss non-increasing by mixed key,
+// as described for Options.Descending. Unlike sort.Reverse(ByMixedKey(ss)),
+// strings with equal mixed keys remain in ascending lexicographic order, so
+// for example
+//
+//	file10 file2 file01 file1
+//
+// is ordered descending by key, but "file01" and "file1" keep their
+// ascending order.
+func ByMixedKeyDesc(ss []string) sort.Interface {
+	return (&Options{Descending: true}).ByMixedKey(ss)
+}
+
+// ByMixedKeyAttached returns a sorter that orders ss non-decreasing by mixed
+// key, where the runes of attached are glued to an adjacent value as its unit,
+// as described for Options.Attached. For example, with attached "%",
+//
+//	load5% load50% load100% loader
+//
+// are in order, and each value is preceded by the run "load".
+func ByMixedKeyAttached(ss []string, attached string) sort.Interface {
+	return (&Options{Attached: attached}).ByMixedKey(ss)
+}
+
+// ByMixedKeyPrefix returns a sorter that orders ss non-decreasing by the
+// mixed keys of the strings with prefix removed, as described for
+// Options.TrimPrefix. Strings that lack the prefix are parsed unchanged. Ties
+// on key order are broken using the lexicographic order of the original
+// strings.
+func ByMixedKeyPrefix(ss []string, prefix string) sort.Interface {
+	return (&Options{TrimPrefix: prefix}).ByMixedKey(ss)
+}
+
+// ByMixedKeyTransform returns a sorter that orders ss non-decreasing by the
+// mixed keys of the strings returned by transform for each string, as
+// described for Options.Transform. The transform is called once per string,
+// and the keys are precomputed at the point of construction. It must be
+// deterministic, returning the same output for the same input. Ties on key
+// order are broken using the lexicographic order of the original strings.
+//
+// Several normalizations can be combined in one transform, for example:
+//
+//	ByMixedKeyTransform(ss, func(s string) string {
+//		return strings.ToLower(strings.TrimSpace(s))
+//	})
+func ByMixedKeyTransform(ss []string, transform func(string) string) sort.Interface {
+	return (&Options{Transform: transform}).ByMixedKey(ss)
+}
+
+// ByMixedKeyTrimmed returns a sorter that orders ss non-decreasing by the
+// mixed keys of the strings with leading and trailing whitespace removed, as
+// by strings.TrimSpace. Whitespace within a string is preserved, so "  file 2"
+// and "file 2 " have equal keys, but "file  2" does not. Ties on key order
+// are broken using the lexicographic order of the original strings, so
+// "  file 2" < "file 2" < "file 2 ".
+func ByMixedKeyTrimmed(ss []string) sort.Interface {
+	return ByMixedKeyTransform(ss, strings.TrimSpace)
+}
+
+// ByMixedKeyWidth returns a sorter that orders ss non-decreasing by mixed key,
+// where digit runs with equal values are ordered by the number of digits in
+// the original string, as described for Options.DigitOrder. If shorterFirst
+// is true, runs with fewer leading zeros sort first, so that
+//
+//	echo1 echo01 echo001
+//
+// are in order; otherwise the order of these strings is reversed.
+func ByMixedKeyWidth(ss []string, shorterFirst bool) sort.Interface {
+	order := LongerFirst
+	if shorterFirst {
+		order = ShorterFirst
+	}
+	return (&Options{DigitOrder: order}).ByMixedKey(ss)
+}
+
+// ByMixedKeyIgnoring returns a sorter that orders ss non-decreasing by mixed
+// key, ignoring the runes of ignore in the non-digit runs of the keys, as
+// described for Options.Ignore. For example, with ignore " #",
+//
+//	Item #2 Item2 Item 10 Item#10
+//
+// are in order, since the keys of "Item #2" and "Item2" are equal, as are
+// those of "Item 10" and "Item#10". Ties on key order are broken using the
+// lexicographic order of the original strings.
+func ByMixedKeyIgnoring(ss []string, ignore string) sort.Interface {
+	return (&Options{Ignore: ignore}).ByMixedKey(ss)
+}
+
+// ByMixedKeyField returns a sorter that orders ss non-decreasing by the mixed
+// key of a single field of each string, where fields are separated by sep and
+// numbered from 0. If sep == "", fields are separated by runs of whitespace,
+// as by strings.Fields. A string with too few fields has an empty key for the
+// missing field. Ties on key order are broken using the lexicographic order
+// of the whole strings. For example, with sep "," and field 1,
+//
+//	c b,item2,x a,item10,y
+//
+// are in order.
+func ByMixedKeyField(ss []string, sep string, field int) sort.Interface {
+	return (&Options{Transform: func(s string) string {
+		return fieldOf(s, sep, field)
+	}}).ByMixedKey(ss)
+}
+
+// fieldOf returns field i of s, where fields are separated by sep, or by runs
+// of whitespace if sep == "". It returns "" if s has no field i.
+func fieldOf(s, sep string, i int) string {
+	if i < 0 {
+		return ""
+	} else if sep == "" {
+		if fs := strings.Fields(s); i < len(fs) {
+			return fs[i]
+		}
+		return ""
+	}
+	for ; i > 0; i-- {
+		_, rest, ok := strings.Cut(s, sep)
+		if !ok {
+			return ""
+		}
+		s = rest
+	}
+	f, _, _ := strings.Cut(s, sep)
+	return f
+}
+
+// ByMixedKeys returns a sorter that orders ss non-decreasing by a compound
+// key, whose fields are the strings returned by split for each string. The
+// fields are compared in turn by mixed key, and a string with fewer fields
+// than another has empty keys for the missing fields. The split function is
+// called once per string, and the keys are precomputed at the point of
+// construction. Ties on key order are broken using the lexicographic order of
+// the original strings. For example, with a split function that separates
+// "Category - Name" into its category and name,
+//
+//	a2 - x10 a10 - x2 a10 - x10 b1 - y
+//
+// are in order.
+func ByMixedKeys(ss []string, split func(string) []string) sort.Interface {
+	keys := make([][]MixedKey, len(ss))
+	for i, s := range ss {
+		fields := split(s)
+		keys[i] = make([]MixedKey, len(fields))
+		for j, f := range fields {
+			keys[i][j] = ParseMixed(f)
+		}
+	}
+	return byMixedKeys{ss: ss, keys: keys}
+}
+
+// byMixedKeys implements sort.Interface for strings with compound keys.
+type byMixedKeys struct {
+	ss   []string     // the original slice to be sorted
+	keys [][]MixedKey // the keys of the fields of each string in ss
+}
+
+func (b byMixedKeys) Len() int { return len(b.ss) }
+
+func (b byMixedKeys) Less(i, j int) bool {
+	ki, kj := b.keys[i], b.keys[j]
+	for f := 0; f < len(ki) || f < len(kj); f++ {
+		var x, y MixedKey // a missing field has an empty key
+		if f < len(ki) {
+			x = ki[f]
+		}
+		if f < len(kj) {
+			y = kj[f]
+		}
+		if c := compareMixed(x, y); c != 0 {
+			return c < 0
+		}
+	}
+	return b.ss[i] < b.ss[j]
+}
+
+func (b byMixedKeys) Swap(i, j int) {
+	b.ss[i], b.ss[j] = b.ss[j], b.ss[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
+
+// ByMixedKeyFunc returns a sorter that orders xs non-decreasing by the mixed
+// key of the string returned by key for each element. The key function is
+// called once per element, and the keys are precomputed at the point of
+// construction. Ties on key order are broken using the lexicographic order of
+// the strings returned by key.
+func ByMixedKeyFunc[T any](xs []T, key func(T) string) sort.Interface {
+	kp := byMixedKeyFunc[T]{
+		xs: xs,
+		byMixedKey: byMixedKey{
+			ss:   make([]string, len(xs)),
+			keys: make([]MixedKey, len(xs)),
+		},
+	}
+	for i, x := range xs {
+		s := key(x)
+		kp.ss[i] = s
+		kp.keys[i] = ParseMixed(s)
+	}
+	return kp
+}
+
+// ByMixedKeyStringer returns a sorter that orders xs non-decreasing by the
+// mixed key of the String method of each element. It is equivalent to
+// ByMixedKeyFunc(xs, T.String), so String is called once per element, and
+// ties are broken using the lexicographic order of the String values.
+func ByMixedKeyStringer[T fmt.Stringer](xs []T) sort.Interface {
+	return ByMixedKeyFunc(xs, T.String)
+}
+
+// byMixedKeyFunc implements sort.Interface for arbitrary values using the
+// mixed keys of strings derived from them.
+type byMixedKeyFunc[T any] struct {
+	xs []T // the original slice to be sorted
+	byMixedKey
+}
+
+func (b byMixedKeyFunc[T]) Swap(i, j int) {
+	b.xs[i], b.xs[j] = b.xs[j], b.xs[i]
+	b.byMixedKey.Swap(i, j)
+}
+
+// Compare compares a and b by mixed key, and returns -1 if a < b, 0 if a == b,
+// or +1 if a > b. This is the same order used by ByMixedKey: Strings whose
+// mixed keys are equal are ordered by the lexicographic order of the original
+// strings, so Compare(a, b) == 0 if and only if a == b.
+//
+// Compare satisfies the comparison contract of slices.SortFunc and related
+// functions, so for example
+//
+//	slices.SortFunc(ss, stringsort.Compare)
+//
+// sorts ss into the same order as sort.Sort(ByMixedKey(ss)).
+//
+// Compare parses a and b on each call. To compare the same strings many
+// times, it is more efficient to use ByMixedKey, which precomputes the keys.
+func Compare(a, b string) int {
+	if a == b {
+		return 0 // no need to parse identical strings
+	}
+	return (*Options)(nil).Compare(a, b)
+}
+
+// CompareFunc is an alias for Compare, named for use as the comparison
+// function of slices.SortFunc, slices.BinarySearchFunc, and the like:
+//
+//	slices.SortFunc(ss, stringsort.CompareFunc)
+func CompareFunc(a, b string) int { return Compare(a, b) }
+
+// CompareNoAlloc compares a and b by mixed key, and returns the same result
+// as Compare(a, b). Unlike Compare, it does not allocate: It compares the
+// spans of a and b as it scans them, without constructing their keys. This
+// makes it suitable for use in a comparison function that is called many
+// times on raw strings, for example
+//
+//	sort.Slice(ss, func(i, j int) bool {
+//		return stringsort.CompareNoAlloc(ss[i], ss[j]) < 0
+//	})
+//
+// though as with Compare, the strings are scanned anew on each call.
+func CompareNoAlloc(a, b string) int {
+	if a == b {
+		return 0
+	} else if c := compareScan(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// EqualMixed reports whether a and b have equal mixed keys, as parsed by
+// ParseMixed. For example, "echo1" and "echo01" have equal keys. This is a
+// test of key equality, not string identity: Compare breaks ties between
+// such strings by their lexicographic order, so Compare(a, b) == 0 only if
+// a == b, whereas EqualMixed reports true.
+//
+// EqualMixed does not construct the keys of a and b, and stops at the first
+// span that differs.
+func EqualMixed(a, b string) bool { return a == b || compareScan(a, b) == 0 }
+
+// compareScan compares the mixed keys of a and b, scanning their spans in
+// turn without constructing the keys. Unlike CompareNoAlloc, it does not
+// break ties.
+func compareScan(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		// Each span is a run of non-digits followed by a run of digits,
+		// either of which may be empty, but not both.
+		ra, da, ni := nextSpan(a, i)
+		rb, db, nj := nextSpan(b, j)
+		if c := strings.Compare(ra, rb); c != 0 {
+			return c
+		} else if (da == "") != (db == "") {
+			// A span without a value is less than a span with one.
+			if da == "" {
+				return -1
+			}
+			return 1
+		} else if c := compareNum(trimZeros(da), trimZeros(db)); c != 0 {
+			return c
+		}
+		i, j = ni, nj
+	}
+	// At least one of the strings is exhausted. If the other is not, it has
+	// more spans, and so its key is greater.
+	return compareInt(len(a)-i, len(b)-j)
+}
+
+// nextSpan scans the span of the mixed key of s beginning at offset i, and
+// returns its non-digit run, its digits, and the offset of the next span.
+func nextSpan(s string, i int) (run, digits string, next int) {
+	start := i
+	for i < len(s) && !isDigit(s[i]) {
+		i++
+	}
+	mid := i
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[start:mid], s[mid:i], i
+}
+
+// compareStrings compares strings a and b having mixed keys ka and kb.
+// Ties on key order are broken using the lexicographic order of a and b.
+func (o *Options) compareStrings(a, b string, ka, kb MixedKey) int {
+	if c := o.compareMixed(ka, kb); c != 0 {
+		if o.descending() {
+			return -c
+		}
+		return c
+	}
+	// If the caller provided a tie-breaker, it takes precedence.
+	if tie := o.tieBreak(); tie != nil {
+		if v := tie(a, b); v != 0 {
+			return v
+		}
+	}
+
+	// Break ties using lexicographic order, to ensure deterministic output.
+	// If there is a collator, it takes precedence, so that the order of ties
+	// is consistent with the order of the runs.
+	if c := o.collator(); c != nil {
+		if v := c.CompareString(a, b); v != 0 {
+			return v
+		}
+	}
+	return strings.Compare(a, b)
+}
+
 // A MixedKey is a lexicographic sort key for a string that partitions it into
 // paired runs of non-digits and decimal digits. The runs of digits are
-// interpreted as integer values for comparison.
+// interpreted as integer values for comparison. There is no limit on the
+// length of a digit run: Values are compared exactly regardless of magnitude.
 //
 // For example, the string "alpha25bravo-3" generates the mixed key:
 //
@@ -74,34 +650,322 @@ func (b byMixedKey) Swap(i, j int) {
 //
 // while the string "101 dalmatians" generates the mixed key:
 //
-//	("", 101) (" dalmatians", 0)
-type MixedKey []nspan
+//	("", 101) (" dalmatians")
+//
+// Note that the last span of a key may have no value, as here. A span with no
+// value is distinct from a span whose value is zero, and sorts before it, so
+// "x" < "x0" by mixed key. Options.ValuesFirst reverses this policy.
+//
+// The empty string, and only the empty string, has an empty key, with no
+// spans. An empty key is less than any non-empty key, so "" sorts before all
+// other strings in ascending order. Among the shortest non-empty strings, a
+// string that begins with a digit sorts before one that begins with a
+// non-digit, since its first run is empty, so for example
+//
+//	"" < "0" < " " < "a"
+//
+// The spans of a key can be inspected by indexing or ranging over the key.
+type MixedKey []Span
+
+// String renders k in the notation used in the documentation of MixedKey,
+// for example ("alpha", 25) ("bravo-", 3). A span without a value is rendered
+// as ("text"), and an empty key is rendered as "()". As in the encoding of
+// MarshalText, a unit follows its value after an "@", as in ("load", 50@"%"),
+// a file extension is marked with a "*", as in ("img", 2) *(".png"), and a
+// span that begins a pre-release is marked with a "~", as in ("", 1) ~("-rc").
+func (k MixedKey) String() string {
+	if len(k) == 0 {
+		return "()"
+	}
+	var sb strings.Builder
+	for i, span := range k {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		if span.ext {
+			sb.WriteByte('*')
+		} else if span.pre {
+			sb.WriteByte('~')
+		}
+		if !span.hasNum {
+			fmt.Fprintf(&sb, "(%q)", span.run)
+		} else if span.unit != "" {
+			fmt.Fprintf(&sb, "(%q, %s@%q)", span.run, span.Value(), span.unit)
+		} else {
+			fmt.Fprintf(&sb, "(%q, %s)", span.run, span.Value())
+		}
+	}
+	return sb.String()
+}
+
+// Compare compares k and other lexicographically by span, and returns -1 if
+// k < other, 0 if k == other, or +1 if k > other. Spans are compared first by
+// their non-digit runs and then by their values, where a span without a
+// value is less than any span with a value. If one key is a prefix of the
+// other, the shorter key is less, unless the longer key continues with a
+// pre-release (see Options.PreRelease).
+//
+// Compare always uses the default comparison rules, whatever options were
+// used to parse the keys. Options that affect how keys are compared rather
+// than how they are parsed have no effect: these are Descending, DigitOrder,
+// LengthFirst, FixedWidth, ValuesFirst, ValuesDescending, Collator, and
+// TieBreak. To compare strings with those options, use Options.Compare.
+func (k MixedKey) Compare(other MixedKey) int { return compareMixed(k, other) }
 
-// ParseMixed parses s into a MixedKey.
-func ParseMixed(s string) MixedKey {
-	var out MixedKey
+// Reconstruct returns a canonical string form of k, the concatenation of the
+// non-digit run of each span followed by its value in decimal, as reported
+// by the Value method of the span, and its unit (see Options.Attached), if
+// any. For example, the key of "file007.txt" is reconstructed as "file7.txt".
+//
+// The result is not the original string if the original had leading zeros,
+// or a value not written in plain decimal (such as a hexadecimal value or a
+// date), or was modified by the options used to parse it (such as FoldCase),
+// but keys that are equal have the same reconstruction.
+func (k MixedKey) Reconstruct() string {
+	var sb strings.Builder
+	for _, span := range k {
+		sb.WriteString(span.run)
+		sb.WriteString(span.Value())
+		sb.WriteString(span.unit)
+	}
+	return sb.String()
+}
+
+// NumericRuns returns the number of spans of k that have a value.
+func (k MixedKey) NumericRuns() int {
+	var n int
+	for _, span := range k {
+		if span.hasNum {
+			n++
+		}
+	}
+	return n
+}
+
+// Equal reports whether k and other are equal, meaning they have the same
+// number of spans, and corresponding spans have equal non-digit runs and
+// values. For example, the keys of "xyzzy1" and "xyzzy01" are equal. Equal
+// reports true exactly when k.Compare(other) == 0.
+func (k MixedKey) Equal(other MixedKey) bool {
+	if len(k) != len(other) {
+		return false
+	}
+	for i, span := range k {
+		o := other[i]
+		if span.run != o.run || span.unit != o.unit || span.ext != o.ext || span.pre != o.pre ||
+			span.hasNum != o.hasNum || span.neg != o.neg || span.num != o.num || span.frac != o.frac {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a hash of k, such that keys reported equal by Equal have equal
+// hashes. For example, the keys of "echo1" and "echo01" have equal hashes.
+// This is useful to assign strings with equal keys to the same bucket or
+// shard, for example when sorting or grouping strings across machines.
+//
+// The hash is a 64-bit FNV-1a hash of an encoding of the non-digit runs,
+// values, and other attributes compared by Equal, so it is the same on every
+// platform and in every run of a program. Keys that are not equal may
+// nonetheless have equal hashes, so a match must be confirmed with Equal.
+func (k MixedKey) Hash() uint64 {
+	h := fnv.New64a()
+	var buf []byte
+	for _, span := range k {
+		var flags byte
+		for i, f := range []bool{span.hasNum, span.neg, span.ext, span.pre} {
+			if f {
+				flags |= 1 << i
+			}
+		}
+		buf = append(buf[:0], flags)
+		for _, f := range []string{span.run, span.num, span.frac, span.unit} {
+			buf = binary.AppendUvarint(buf, uint64(len(f)))
+			buf = append(buf, f...)
+		}
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+// ParseMixed parses s into a MixedKey. If s == "", the key is empty.
+//
+// Each maximal run of digits in s is a separate value, and the non-digit
+// text between two values is kept as the run of the span of the second, so
+// values separated by even a single character are never merged. For example,
+// "1 2 3" has the key ("", 1) (" ", 2) (" ", 3), while "123" has the key
+// ("", 123). Only options that explicitly accept separators within a value,
+// such as Options.GroupSeparator and Options.Float, combine digit runs.
+func ParseMixed(s string) MixedKey { return parseMixed(s, nil, nil) }
+
+// ParseMixedInto parses s into a MixedKey, as ParseMixed, reusing the storage
+// of buf for the result if its capacity is sufficient. The contents of buf
+// are overwritten, and buf should not be used after the call except via the
+// returned key.
+//
+// For example, to parse many strings without allocating a new key for each:
+//
+//	var buf stringsort.MixedKey
+//	for _, s := range ss {
+//		buf = stringsort.ParseMixedInto(s, buf)
+//		// ... use buf ...
+//	}
+func ParseMixedInto(s string, buf MixedKey) MixedKey { return parseMixed(s, nil, buf) }
+
+// ParseMixedStrict parses s into a MixedKey, as ParseMixed, but reports an
+// error without parsing s if it is not valid UTF-8.
+func ParseMixedStrict(s string) (MixedKey, error) { return (*Options)(nil).ParseStrict(s) }
+
+// KeysFor returns the mixed keys of ss, in the same order as ss. The result
+// has the same length as ss, and keys[i] == ParseMixed(ss[i]).
+func KeysFor(ss []string) []MixedKey {
+	keys := make([]MixedKey, len(ss))
+	for i, s := range ss {
+		keys[i] = ParseMixed(s)
+	}
+	return keys
+}
+
+// ParseMixedSigned parses s into a MixedKey, treating a "-" or "+" immediately
+// before a run of digits as the sign of the value, as described for
+// Options.Signed.
+//
+// For example, the string "temp-10" generates the mixed key:
+//
+//	("temp", -10)
+func ParseMixedSigned(s string) MixedKey { return parseMixed(s, &Options{Signed: true}, nil) }
+
+// ParseMixedFloat parses s into a MixedKey, treating a run of digits followed
+// by "." and another run of digits as a single decimal value, as described
+// for Options.Float.
+//
+// For example, the string "sample2.5g" generates the mixed key:
+//
+//	("sample", 2.5) ("g")
+func ParseMixedFloat(s string) MixedKey { return parseMixed(s, &Options{Float: true}, nil) }
+
+// parseMixed parses s into a MixedKey using the digit rules of o. If buf has
+// nonzero capacity, its storage is reused for the result.
+func parseMixed(s string, o *Options, buf MixedKey) MixedKey {
+	out := buf[:0]
+	if cap(out) == 0 {
+		if n := countSpans(s); n > 0 {
+			out = make(MixedKey, 0, n)
+		}
+	}
 
 	i, end := 0, 0
 	for i < len(s) {
 		// Scan for a digit
-		ch := s[i]
-		if ch < '0' || ch > '9' {
-			i++
+		_, size, ok := o.scanDigit(s, i)
+		if !ok {
+			i += size
 			continue
 		}
 
 		// Having found a digit, start a new span with the run prior to the
 		// digit.  Consume digits until a non-digit or end-of-string.  Note the
 		// prior span may be empty, if the string begins with digits.
-		cur := nspan{run: s[end:i], n: int(ch - '0')}
-		i++
-		for i < len(s) {
-			ch := s[i]
-			if ch < '0' || ch > '9' {
-				break
+		start := i
+		signed := o.signed()
+		var cur Span
+		if date, next := o.scanDate(s, start); next > start {
+			// A date, whose value has the form YYYYMMDD so that dates compare
+			// chronologically. Dates do not have signs.
+			i = next
+			cur = Span{run: s[end:start], num: trimZeros(date), hasNum: true}
+			if o.recordDigits() {
+				cur.digits = date
+			}
+			signed = false
+		} else if o.hex() && isHexPrefix(s, start) {
+			// A hexadecimal value, which is converted to decimal so that it
+			// compares correctly with other values.
+			i += 2
+			for limit := o.maxDigits(); i < len(s) && isHexDigit(s[i]); i++ {
+				if limit > 0 && i-start-2 == limit {
+					break
+				}
+			}
+			cur = Span{run: s[end:start], num: baseToDecimal(s[start+2:i], 16), hasNum: true}
+			if o.recordDigits() {
+				cur.digits = s[start+2 : i]
+			}
+		} else {
+			var digits string
+			digits, i = o.scanDigits(s, start, true)
+			cur = Span{run: s[end:start], num: trimZeros(digits), hasNum: true}
+			if o.recordDigits() {
+				cur.digits = digits
+			}
+
+			// If a decimal point is followed by more digits, they are the
+			// fractional part of the value.
+			fraction := false
+			if o.float() && i+1 < len(s) && s[i] == '.' {
+				if _, _, ok := o.scanDigit(s, i+1); ok {
+					var fdigits string
+					fdigits, i = o.scanDigits(s, i+1, false)
+					cur.frac = strings.TrimRight(fdigits, "0")
+					fraction = true
+				}
+			}
+			if exp, next := o.scanExponent(s, i); next > i {
+				cur.num, cur.frac = scaleDecimal(cur.num, cur.frac, exp)
+				i = next
+			} else if !fraction && o.octal() && isOctal(digits) {
+				cur.num = baseToDecimal(digits, 8)
+			}
+
+			// If the value has a duration unit, convert it to seconds.
+			if secs := o.scanDuration(s, i); secs > 0 {
+				cur.num, cur.frac = multiplyDecimal(cur.num, cur.frac, secs)
+				i++
+			}
+		}
+
+		if o.roundFloat64() {
+			cur.num, cur.frac = roundFloat64(cur.num, cur.frac)
+		}
+
+		// If the digits have a prefix, it belongs to the value, not the run.
+		// The sign, if any, precedes the prefix.
+		lead := start // the offset of the value, including its prefix
+		if set := o.valuePrefix(); set != "" && start > end {
+			r, size := utf8.DecodeLastRuneInString(s[end:start])
+			if strings.ContainsRune(set, r) {
+				lead -= size
+				cur.run = s[end:lead]
+			}
+		}
+
+		// If the digits have a sign, it belongs to the value, not the run.
+		if sign := o.scanSign(s, lead); signed && sign != 0 && lead > end {
+			cur.run = s[end : lead-1]
+			cur.neg = sign == '-' && !cur.isZero() // there is no negative zero
+		}
+
+		// Attached runes on either side of the value belong to its unit.
+		if set := o.attached(); set != "" {
+			k := len(cur.run)
+			for k > 0 {
+				r, size := utf8.DecodeLastRuneInString(cur.run[:k])
+				if !strings.ContainsRune(set, r) {
+					break
+				}
+				k -= size
 			}
-			cur.n = 10*cur.n + int(ch-'0')
-			i++
+			j := i
+			for j < len(s) {
+				r, size := utf8.DecodeRuneInString(s[j:])
+				if !strings.ContainsRune(set, r) {
+					break
+				}
+				j += size
+			}
+			cur.run, cur.unit = cur.run[:k], cur.run[k:]+s[i:j]
+			i = j
 		}
 		out = append(out, cur)
 		end = i
@@ -109,11 +973,333 @@ func ParseMixed(s string) MixedKey {
 
 	// Ensure a non-empty trailing run is captured.
 	if end < i {
-		out = append(out, nspan{run: s[end:i]})
+		out = append(out, Span{run: s[end:i]})
 	}
 	return out
 }
 
+// NumericRuns returns the number of runs of digits in s, which is the number
+// of spans with values in the key of s. It is equivalent to
+// ParseMixed(s).NumericRuns(), but does not construct the key. If s contains
+// no digits, NumericRuns returns 0.
+func NumericRuns(s string) int {
+	n, inDigits := 0, false
+	for i := 0; i < len(s); i++ {
+		d := isDigit(s[i])
+		if d && !inDigits {
+			n++
+		}
+		inDigits = d
+	}
+	return n
+}
+
+// countSpans returns an estimate of the number of spans in the mixed key for
+// s, based on the number of runs of ASCII digits. This is exact unless the
+// options used to parse s combine or add digit runs.
+func countSpans(s string) int {
+	n := NumericRuns(s)
+	if len(s) > 0 && !isDigit(s[len(s)-1]) {
+		n++ // trailing non-digit run
+	}
+	return n
+}
+
+// scanDigit reports whether s has a digit at offset i, and if so returns its
+// value as an ASCII digit. It also returns the length in bytes of the
+// character at offset i, whether or not it is a digit.
+func (o *Options) scanDigit(s string, i int) (digit byte, size int, ok bool) {
+	if f := o.classify(); f != nil {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if f(r) == Digit && unicode.IsDigit(r) {
+			return '0' + digitValue(r), size, true
+		}
+		return 0, size, false
+	}
+	if f := o.digitFunc(); f != nil {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if v, ok := f(r); ok && v >= 0 && v <= 9 {
+			return '0' + byte(v), size, true
+		}
+		return 0, size, false
+	}
+	if ch := s[i]; ch < utf8.RuneSelf || !o.unicodeDigits() {
+		return ch, 1, isDigit(ch)
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	if unicode.IsDigit(r) {
+		return '0' + digitValue(r), size, true
+	}
+	return 0, size, false
+}
+
+// scanDigits scans the run of digits beginning at offset i of s, and returns
+// the run as a string of ASCII digits along with the offset of the end of the
+// run. The run ends after Options.MaxDigits digits, if set. If grouped is
+// true, the run may include group separators (see Options.GroupSeparator),
+// which are omitted from the result. If the run consists only of ASCII digits
+// with their usual values, the result is a substring of s; otherwise it is a
+// copy.
+func (o *Options) scanDigits(s string, i int, grouped bool) (string, int) {
+	j, plain := i, true
+	limit, n := o.maxDigits(), 0 // n counts the digits consumed
+	for j < len(s) && (limit <= 0 || n < limit) {
+		d, size, ok := o.scanDigit(s, j)
+		if ok {
+			n++
+		} else if !grouped {
+			break
+		} else if size = o.scanSeparator(s, j); size == 0 {
+			break // not a group separator followed by a digit
+		}
+		plain = plain && ok && size == 1 && d == s[j]
+		j += size
+	}
+	if plain {
+		return s[i:j], j
+	}
+	buf := make([]byte, 0, j-i)
+	for k := i; k < j; {
+		d, size, ok := o.scanDigit(s, k)
+		if ok {
+			buf = append(buf, d)
+		}
+		k += size
+	}
+	return string(buf), j
+}
+
+// maxExponent is the largest exponent magnitude recognized by scanExponent.
+// It bounds the length of the normalized value of a number in scientific
+// notation.
+const maxExponent = 999
+
+// scanExponent reports whether s has an exponent at offset i, as described
+// for Options.Exponent. If so, it returns the value of the exponent and the
+// offset of the first byte following it. Otherwise it returns 0, i.
+func (o *Options) scanExponent(s string, i int) (int, int) {
+	if !o.exponent() || i >= len(s) || (s[i] != 'e' && s[i] != 'E') {
+		return 0, i
+	}
+	j, neg := i+1, false
+	if j < len(s) && (s[j] == '+' || s[j] == '-') {
+		neg = s[j] == '-'
+		j++
+	}
+	if j >= len(s) {
+		return 0, i
+	} else if _, _, ok := o.scanDigit(s, j); !ok {
+		return 0, i
+	}
+	digits, next := o.scanDigits(s, j, false)
+	digits = trimZeros(digits)
+	if len(digits) > len(strconv.Itoa(maxExponent)) {
+		return 0, i // the value would be too large to represent
+	}
+	exp, _ := strconv.Atoi("0" + digits)
+	if exp > maxExponent {
+		return 0, i
+	} else if neg {
+		exp = -exp
+	}
+	return exp, next
+}
+
+// scanDuration reports whether s has a duration unit at offset i, as
+// described for Options.Durations. If so, it returns the number of seconds
+// in the unit; otherwise it returns 0.
+func (o *Options) scanDuration(s string, i int) int64 {
+	if !o.durations() || i >= len(s) {
+		return 0
+	} else if r, _ := utf8.DecodeRuneInString(s[i+1:]); i+1 < len(s) && unicode.IsLetter(r) {
+		return 0 // part of a longer word, as in "5min"
+	}
+	switch s[i] {
+	case 's':
+		return 1
+	case 'm':
+		return 60
+	case 'h':
+		return 60 * 60
+	case 'd':
+		return 24 * 60 * 60
+	}
+	return 0
+}
+
+// multiplyDecimal returns the integer and fractional digits of the decimal
+// value with digits num and frac multiplied by m. Leading zeros of the
+// integer part and trailing zeros of the fraction are removed.
+func multiplyDecimal(num, frac string, m int64) (string, string) {
+	var x big.Int
+	x.SetString("0"+num+frac, 10)
+	x.Mul(&x, big.NewInt(m))
+	return scaleDecimal(x.String(), "", -len(frac))
+}
+
+// roundFloat64 returns the integer and fractional digits of the decimal value
+// num.frac rounded to the nearest float64, as described for
+// Options.RoundFloat64. The result is the shortest decimal that rounds to the
+// same float64, so values that round to the same float64 have equal digits.
+func roundFloat64(num, frac string) (string, string) {
+	if len(num)+len(frac) <= 15 {
+		return num, frac // at most 15 significant digits are exact
+	}
+	v, err := strconv.ParseFloat(num+"."+frac, 64)
+	if err != nil {
+		v = math.MaxFloat64 // the value is too large to represent
+	}
+	num, frac, _ = strings.Cut(strconv.FormatFloat(v, 'f', -1, 64), ".")
+	return trimZeros(num), frac
+}
+
+// scaleDecimal returns the integer and fractional digits of the decimal value
+// with digits num and frac multiplied by 10^exp. Leading zeros of the integer
+// part and trailing zeros of the fraction are removed.
+func scaleDecimal(num, frac string, exp int) (string, string) {
+	digits := num + frac
+	point := len(num) + exp // the offset of the decimal point in digits
+	switch {
+	case point <= 0:
+		num, frac = "", strings.Repeat("0", -point)+digits
+	case point >= len(digits):
+		num, frac = digits+strings.Repeat("0", point-len(digits)), ""
+	default:
+		num, frac = digits[:point], digits[point:]
+	}
+	return trimZeros(num), strings.TrimRight(frac, "0")
+}
+
+// scanSeparator reports the length in bytes of the group separator at offset
+// i of s, or 0 if there is no group separator at that offset or it is not
+// followed by a digit.
+func (o *Options) scanSeparator(s string, i int) int {
+	sep := o.groupSeparator()
+	if sep == 0 {
+		return 0
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	if r != sep || i+size >= len(s) {
+		return 0
+	} else if _, _, ok := o.scanDigit(s, i+size); !ok {
+		return 0
+	}
+	return size
+}
+
+// scanDate reports whether s has a valid calendar date of the form
+// YYYY-MM-DD or YYYY/MM/DD at offset i, where the month and day may have one
+// or two digits. If so, it returns the date as an 8-digit string YYYYMMDD
+// along with the offset of the end of the date. Otherwise it returns "", i.
+func (o *Options) scanDate(s string, i int) (string, int) {
+	if !o.dates() {
+		return "", i
+	}
+	y, j := scanASCIIDigits(s, i)
+	if len(y) != 4 || j >= len(s) || (s[j] != '-' && s[j] != '/') {
+		return "", i
+	}
+	sep := s[j]
+	m, k := scanASCIIDigits(s, j+1)
+	if len(m) < 1 || len(m) > 2 || k >= len(s) || s[k] != sep {
+		return "", i
+	}
+	d, end := scanASCIIDigits(s, k+1)
+	if len(d) < 1 || len(d) > 2 {
+		return "", i
+	}
+
+	// Check that the date is valid, for example not February 30.
+	year, _ := strconv.Atoi(y)
+	month, _ := strconv.Atoi(m)
+	day, _ := strconv.Atoi(d)
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day {
+		return "", i
+	}
+	return fmt.Sprintf("%04d%02d%02d", year, month, day), end
+}
+
+// scanASCIIDigits returns the run of ASCII digits beginning at offset i of s,
+// and the offset of the end of the run.
+func scanASCIIDigits(s string, i int) (string, int) {
+	j := i
+	for j < len(s) && isDigit(s[j]) {
+		j++
+	}
+	return s[i:j], j
+}
+
+// scanSign returns the sign preceding the digit run beginning at offset i of
+// s, either '-' or '+', or 0 if the run has no sign. A sign must not itself be
+// preceded by a digit.
+func (o *Options) scanSign(s string, i int) byte {
+	if i == 0 || (s[i-1] != '-' && s[i-1] != '+') {
+		return 0
+	} else if i == 1 {
+		return s[0]
+	}
+	_, size := utf8.DecodeLastRuneInString(s[:i-1])
+	if _, _, ok := o.scanDigit(s, i-1-size); ok {
+		return 0
+	}
+	return s[i-1]
+}
+
+func isDigit(ch byte) bool { return ch >= '0' && ch <= '9' }
+
+// digitValue returns the value of r, which must be a decimal digit in the
+// unicode.Nd category. Each range of that table consists of complete sets of
+// ten consecutive digits, each beginning with zero.
+func digitValue(r rune) byte {
+	for _, rng := range unicode.Nd.R16 {
+		if lo := rune(rng.Lo); r >= lo && r <= rune(rng.Hi) {
+			return byte((r - lo) % 10)
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if lo := rune(rng.Lo); r >= lo && r <= rune(rng.Hi) {
+			return byte((r - lo) % 10)
+		}
+	}
+	panic("not a decimal digit")
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
+
+// isHexPrefix reports whether s has a "0x" or "0X" prefix followed by at least
+// one hexadecimal digit at offset i.
+func isHexPrefix(s string, i int) bool {
+	return i+2 < len(s) && s[i] == '0' && (s[i+1] == 'x' || s[i+1] == 'X') && isHexDigit(s[i+2])
+}
+
+// isOctal reports whether s is a string of at least two octal digits with a
+// leading zero.
+func isOctal(s string) bool {
+	if len(s) < 2 || s[0] != '0' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] > '7' {
+			return false
+		}
+	}
+	return true
+}
+
+// baseToDecimal converts a string of digits in the given base into a string
+// of decimal digits without leading zeros.
+func baseToDecimal(s string, base int) string {
+	v, _ := new(big.Int).SetString(s, base) // s is known to be valid
+	return trimZeros(v.String())
+}
+
+// trimZeros returns the digit string s with leading zeros removed.
+// The value zero is represented by the empty string.
+func trimZeros(s string) string { return strings.TrimLeft(s, "0") }
+
 func compareInt(a, b int) int {
 	switch {
 	case a == b:
@@ -125,29 +1311,217 @@ func compareInt(a, b int) int {
 	}
 }
 
-type nspan struct {
-	run string
-	n   int
+// A Span is a single element of a MixedKey, consisting of a run of non-digits
+// followed by an optional numeric value. In a key parsed by ParseMixed, every
+// span has a value except possibly the last, which may consist of only a run
+// of non-digits. Some options produce keys of other shapes, so code that
+// inspects the spans of a key should check HasValue rather than rely on the
+// position of a span:
+//
+//   - With NumberFirst or FirstValue, a key may be rearranged so that its
+//     first span is a value with an empty run, followed by spans without
+//     values holding the text around it, as in ("", 42) ("ABC-") ("-X") for
+//     "ABC-42-X" with NumberFirst.
+//   - With SplitExtension, the extension is a final span without a value,
+//     which may follow another span without a value, as in ("a") *(".txt")
+//     for "a.txt".
+//   - With Revisions, a revision suffix is a span with an empty run and a
+//     value, as in ("A", 100) ("", 2) for "A100B".
+type Span struct {
+	run    string // the run of non-digits
+	num    string // decimal digits of the value, without leading zeros
+	frac   string // fractional digits, without trailing zeros (only if Options.Float)
+	neg    bool   // whether the value is negative (only if Options.Signed)
+	digits string // the original digits of the value (only if Options.DigitOrder)
+	unit   string // attached runes adjacent to the value (only if Options.Attached)
+	ext    bool   // whether the span is a file extension (only if Options.SplitExtension)
+	pre    bool   // whether the span begins a pre-release (only if Options.PreRelease)
+	hasNum bool   // whether the span has a value
 }
 
-func compareNspan(a, b nspan) int {
-	if a.run == b.run {
-		return compareInt(a.n, b.n)
-	} else if a.run < b.run {
+// Text returns the run of non-digits of the span, which may be empty.
+func (n Span) Text() string { return n.run }
+
+// HasValue reports whether the span has a numeric value.
+func (n Span) HasValue() bool { return n.hasNum }
+
+// Unit returns the attached runes adjacent to the value of the span, which
+// may be empty (see Options.Attached).
+func (n Span) Unit() string { return n.unit }
+
+// Value returns the value of the span as a decimal string, without leading
+// zeros, for example "25", "0", or "-1.5". If the span has no value, Value
+// returns "".
+func (n Span) Value() string {
+	if !n.hasNum {
+		return ""
+	}
+	v := n.num
+	if v == "" {
+		v = "0"
+	}
+	if n.frac != "" {
+		v += "." + n.frac
+	}
+	if n.neg {
+		return "-" + v
+	}
+	return v
+}
+
+// Compare compares n and other, and returns -1 if n < other, 0 if n == other,
+// or +1 if n > other. Spans are ordered first by their non-digit runs, and
+// then by their values, where a span without a value is less than any span
+// with a value. This is the order used to compare the spans of keys by
+// MixedKey.Compare, and likewise always uses the default comparison rules:
+// the DigitOrder, LengthFirst, FixedWidth, ValuesFirst, ValuesDescending, and
+// Collator options have no effect, even if the spans were parsed using them.
+func (n Span) Compare(other Span) int { return compareSpan(n, other) }
+
+// isZero reports whether the value of the span is zero.
+func (n Span) isZero() bool { return n.num == "" && n.frac == "" }
+
+// compareNum compares the values of two digit strings without leading zeros.
+// Since there are no leading zeros, a longer string has a larger value, and
+// strings of the same length compare lexicographically.
+func compareNum(a, b string) int {
+	if c := compareInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// compareValue compares the signed values of a and b. A span without a value
+// is less than any span with a value.
+func compareValue(a, b Span) int {
+	if a.hasNum != b.hasNum {
+		if a.hasNum {
+			return 1
+		}
 		return -1
+	} else if a.neg != b.neg {
+		if a.neg {
+			return -1
+		}
+		return 1
+	} else if a.neg {
+		a, b = b, a // reverse the order of magnitudes
+	}
+	if c := compareNum(a.num, b.num); c != 0 {
+		return c
 	}
-	return 1
+	// Without trailing zeros, fractional parts compare lexicographically.
+	return strings.Compare(a.frac, b.frac)
 }
 
-func compareMixed(a, b MixedKey) int {
+func compareSpan(a, b Span) int { return (*Options)(nil).compareSpan(a, b) }
+
+// compareSpan compares spans a and b according to the options.
+func (o *Options) compareSpan(a, b Span) int {
+	if a.pre != b.pre {
+		// A pre-release is less than any other continuation of the string,
+		// including an extension, just as it is less than the end of a key.
+		if a.pre {
+			return -1
+		}
+		return 1
+	} else if a.ext != b.ext {
+		// An extension follows the whole base name, so it is less than any
+		// span of a longer base name.
+		if a.ext {
+			return -1
+		}
+		return 1
+	} else if c := o.compareRun(a.run, b.run); c != 0 {
+		return c
+	} else if c := strings.Compare(a.unit, b.unit); c != 0 {
+		return c
+	} else if c := o.compareValues(a, b); c != 0 {
+		if a.hasNum != b.hasNum && o.valuesFirst() {
+			return -c
+		} else if o.valuesDescending() && a.hasNum && b.hasNum {
+			return -c
+		}
+		return c
+	}
+	return o.compareDigits(a.digits, b.digits)
+}
+
+// compareRun compares the non-digit runs a and b according to the options.
+func (o *Options) compareRun(a, b string) int {
+	if a == b {
+		return 0
+	} else if c := o.collator(); c != nil {
+		return c.CompareString(a, b)
+	}
+	return strings.Compare(a, b)
+}
+
+// compareValues compares the values of spans a and b, whose runs and units
+// are known to be equal, according to the LengthFirst and FixedWidth options
+// of o.
+func (o *Options) compareValues(a, b Span) int {
+	if o.lengthFirst() && a.hasNum && b.hasNum && a.neg == b.neg {
+		// A longer negative run has a greater magnitude, so it is less.
+		if c := compareInt(len(a.digits), len(b.digits)); c != 0 && a.neg {
+			return -c
+		} else if c != 0 {
+			return c
+		}
+	}
+	if w := o.fixedWidth(); w > 0 && a.hasNum && b.hasNum && a.neg == b.neg {
+		la, lb := len(a.digits), len(b.digits)
+		c := 0
+		if (la <= w) != (lb <= w) {
+			// A run that fits the field is less than one that overflows it.
+			if c = 1; la <= w {
+				c = -1
+			}
+		} else if la <= w {
+			// Right-aligned runs of equal width compare as their values.
+			c = compareInt(la, lb)
+		}
+		if c != 0 && a.neg {
+			return -c // as for LengthFirst
+		} else if c != 0 {
+			return c
+		}
+	}
+	return compareValue(a, b)
+}
+
+// compareDigits compares the original digit strings a and b, whose values
+// are known to be equal, according to the DigitOrder of o.
+func (o *Options) compareDigits(a, b string) int {
+	switch o.digitOrder() {
+	case ShorterFirst:
+		return compareInt(len(a), len(b))
+	case LongerFirst:
+		return compareInt(len(b), len(a))
+	case DigitsLexical:
+		return strings.Compare(a, b)
+	}
+	return 0
+}
+
+func compareMixed(a, b MixedKey) int { return (*Options)(nil).compareMixed(a, b) }
+
+// compareMixed compares keys a and b according to the options.
+func (o *Options) compareMixed(a, b MixedKey) int {
 	n := len(a)
 	if n > len(b) {
 		n = len(b)
 	}
 	for i := 0; i < n; i++ {
-		if c := compareNspan(a[i], b[i]); c != 0 {
+		if c := o.compareSpan(a[i], b[i]); c != 0 {
 			return c
 		}
 	}
+	// A pre-release sorts before the same key without it.
+	if len(a) > n && a[n].pre {
+		return -1
+	} else if len(b) > n && b[n].pre {
+		return 1
+	}
 	return compareInt(len(a), len(b))
 }
@@ -1,12 +1,15 @@
 package stringsort
 
 import (
+	"fmt"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestCompareMixed(t *testing.T) {
@@ -19,24 +22,171 @@ func TestCompareMixed(t *testing.T) {
 		{nil, MixedKey{}, 0},
 		{MixedKey{}, MixedKey{}, 0},
 
-		{MixedKey{{"x", 1}}, nil, 1},
-		{nil, MixedKey{{"x", 1}}, -1},
-		{MixedKey{{"x", 1}}, MixedKey{{"x", 1}}, 0},
+		{MixedKey{sp("x", "1")}, nil, 1},
+		{nil, MixedKey{sp("x", "1")}, -1},
+		{MixedKey{sp("x", "1")}, MixedKey{sp("x", "1")}, 0},
 
-		{MixedKey{{"x", 3}}, MixedKey{{"x", 2}}, 1},
-		{MixedKey{{"x", 2}}, MixedKey{{"x", 2}}, 0},
-		{MixedKey{{"x", 2}}, MixedKey{{"x", 3}}, -1},
+		{MixedKey{sp("x", "3")}, MixedKey{sp("x", "2")}, 1},
+		{MixedKey{sp("x", "2")}, MixedKey{sp("x", "2")}, 0},
+		{MixedKey{sp("x", "2")}, MixedKey{sp("x", "3")}, -1},
 
-		{MixedKey{{"a", 1}}, MixedKey{{"b", 1}}, -1},
-		{MixedKey{{"a", 1}}, MixedKey{{"a", 1}}, 0},
-		{MixedKey{{"b", 1}}, MixedKey{{"a", 1}}, 1},
-		{MixedKey{{"c", 10}}, MixedKey{{"a", 1}}, 1},
+		{MixedKey{sp("a", "1")}, MixedKey{sp("b", "1")}, -1},
+		{MixedKey{sp("a", "1")}, MixedKey{sp("a", "1")}, 0},
+		{MixedKey{sp("b", "1")}, MixedKey{sp("a", "1")}, 1},
+		{MixedKey{sp("c", "10")}, MixedKey{sp("a", "1")}, 1},
+
+		{MixedKey{sp("x", "-10")}, MixedKey{sp("x", "-5")}, -1},
+		{MixedKey{sp("x", "-5")}, MixedKey{sp("x", "0")}, -1},
+		{MixedKey{sp("x", "3")}, MixedKey{sp("x", "-5")}, 1},
+		{MixedKey{sp("x", "-5")}, MixedKey{sp("x", "-5")}, 0},
+
+		{MixedKey{sp("x", "1.1")}, MixedKey{sp("x", "1.2")}, -1},
+		{MixedKey{sp("x", "1.25")}, MixedKey{sp("x", "1.3")}, -1},
+		{MixedKey{sp("x", "1")}, MixedKey{sp("x", "1.01")}, -1},
+		{MixedKey{sp("x", "-1.5")}, MixedKey{sp("x", "-1.25")}, -1},
+		{MixedKey{sp("x", "-0.5")}, MixedKey{sp("x", "0")}, -1},
+
+		// A span without a value is less than any span with a value.
+		{MixedKey{sp("x", "")}, MixedKey{sp("x", "0")}, -1},
+		{MixedKey{sp("x", "0")}, MixedKey{sp("x", "")}, 1},
+		{MixedKey{sp("x", "")}, MixedKey{sp("x", "-5")}, -1},
+		{MixedKey{sp("x", "")}, MixedKey{sp("x", "")}, 0},
+		{MixedKey{sp("x", "0")}, MixedKey{sp("x", "0")}, 0},
 	}
 	for _, test := range tests {
 		got := compareMixed(test.lhs, test.rhs)
 		if got != test.want {
 			t.Errorf("compareMixed(%v, %v): got %v, want %v", test.lhs, test.rhs, got, test.want)
 		}
+		if got := test.lhs.Compare(test.rhs); got != test.want {
+			t.Errorf("%v.Compare(%v): got %v, want %v", test.lhs, test.rhs, got, test.want)
+		}
+	}
+}
+
+func TestMixedKeyCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"xyzzy01", "xyzzy1", 0},
+		{"alpha25bravo-3", "alpha25bravo-3", 0},
+		{"alpha25", "alpha25bravo", -1},
+	}
+	for _, test := range tests {
+		ka, kb := ParseMixed(test.a), ParseMixed(test.b)
+		if got := ka.Compare(kb); got != test.want {
+			t.Errorf("Compare %q, %q: got %v, want %v", test.a, test.b, got, test.want)
+		}
+		if got := kb.Compare(ka); got != -test.want {
+			t.Errorf("Compare %q, %q: got %v, want %v", test.b, test.a, got, -test.want)
+		}
+		if got := ka.Equal(kb); got != (test.want == 0) {
+			t.Errorf("Equal %q, %q: got %v, want %v", test.a, test.b, got, test.want == 0)
+		}
+	}
+}
+
+func TestEmptyOrder(t *testing.T) {
+	if key := ParseMixed(""); len(key) != 0 {
+		t.Errorf("ParseMixed(%q): got %v, want empty", "", key)
+	}
+	if key := ParseMixedInto("", ParseMixed("a1b2")); len(key) != 0 {
+		t.Errorf("ParseMixedInto(%q): got %v, want empty", "", key)
+	}
+
+	want := []string{"", "0", "00", "1", " ", " 0", "-", "a", "a0"}
+	for _, s := range want[1:] {
+		if c := Compare("", s); c != -1 {
+			t.Errorf("Compare(%q, %q): got %d, want -1", "", s, c)
+		}
+		if c := ParseMixed("").Compare(ParseMixed(s)); c != -1 {
+			t.Errorf("Key compare (%q, %q): got %d, want -1", "", s, c)
+		}
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		got := copyStrings(want[i:])
+		got = append(got, want[:i]...)
+		sort.Sort(ByMixedKey(got))
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ByMixedKey (rotation %d): (-want, +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestMixedKeyEqual(t *testing.T) {
+	tests := []struct {
+		a, b MixedKey
+		want bool
+	}{
+		{nil, nil, true},
+		{nil, MixedKey{}, true},
+		{ParseMixed("x"), ParseMixed("x0"), false},
+		{ParseMixed("x0"), ParseMixed("x00"), true},
+		{ParseMixedSigned("x-1"), ParseMixed("x-1"), false},
+		{ParseMixedFloat("v1.50"), ParseMixedFloat("v1.5"), true},
+		{ParseMixedFloat("v1.5"), ParseMixed("v1.5"), false},
+		{(&Options{Attached: "%"}).Parse("5%"), ParseMixed("5%"), false},
+		{(&Options{PreRelease: "-"}).Parse("1-a"), ParseMixed("1-a"), false},
+
+		// Digit order is not considered, as for Compare.
+		{(&Options{DigitOrder: ShorterFirst}).Parse("a01"), ParseMixed("a1"), true},
+	}
+	for _, test := range tests {
+		if got := test.a.Equal(test.b); got != test.want {
+			t.Errorf("%v.Equal(%v): got %v, want %v", test.a, test.b, got, test.want)
+		}
+		if got := test.a.Compare(test.b) == 0; got != test.want {
+			t.Errorf("%v.Compare(%v) == 0: got %v, want %v", test.a, test.b, got, test.want)
+		}
+		if test.want && test.a.Hash() != test.b.Hash() {
+			t.Errorf("%v.Hash() = %x, %v.Hash() = %x: want equal", test.a, test.a.Hash(), test.b, test.b.Hash())
+		}
+	}
+}
+
+func TestMixedKeyHash(t *testing.T) {
+	// The hash must not change, since it may be stored or shared.
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{"", 0xcbf29ce484222325},
+		{"echo1", 0xe1b8a4607d0fdbb3},
+		{"echo01", 0xe1b8a4607d0fdbb3},
+		{"file-10-part2.txt", 0x303b27e3e7d498e8},
+	}
+	for _, test := range tests {
+		if got := ParseMixed(test.input).Hash(); got != test.want {
+			t.Errorf("Hash(%q): got %#x, want %#x", test.input, got, test.want)
+		}
+	}
+
+	// Keys that differ only in how their text is divided have different hashes.
+	distinct := []MixedKey{
+		ParseMixed("ab1"),
+		ParseMixed("a1b"),
+		ParseMixed("a1b1"),
+		ParseMixed("a11"),
+		ParseMixedSigned("a-1"),
+		ParseMixed("a-1"),
+		ParseMixedFloat("a1.1"),
+		ParseMixed("a1.1"),
+		(&Options{SplitExtension: true}).Parse("a1.b"),
+		ParseMixed("a1.b"),
+		MixedKey{sp("a", ""), sp("b", "")},
+	}
+	seen := make(map[uint64]MixedKey)
+	for _, key := range distinct {
+		h := key.Hash()
+		if prev, ok := seen[h]; ok {
+			t.Errorf("Hash %v == Hash %v: got %#x for both", prev, key, h)
+		}
+		seen[h] = key
 	}
 }
 
@@ -46,13 +196,25 @@ func TestParseMixed(t *testing.T) {
 		want  MixedKey
 	}{
 		{"", nil},
-		{"foo", MixedKey{{"foo", 0}}},
-		{"foo 42", MixedKey{{"foo ", 42}}},
-		{"101", MixedKey{{"", 101}}},
-		{"alpha25bravo-3", MixedKey{{"alpha", 25}, {"bravo-", 3}}},
-		{"101 dalmatians", MixedKey{{"", 101}, {" dalmatians", 0}}},
+		{"foo", MixedKey{sp("foo", "")}},
+		{"foo 42", MixedKey{sp("foo ", "42")}},
+		{"101", MixedKey{sp("", "101")}},
+		{"alpha25bravo-3", MixedKey{sp("alpha", "25"), sp("bravo-", "3")}},
+		{"101 dalmatians", MixedKey{sp("", "101"), sp(" dalmatians", "")}},
+		{"007", MixedKey{sp("", "7")}},
+		{"1 2 3", MixedKey{sp("", "1"), sp(" ", "2"), sp(" ", "3")}},
+		{"123", MixedKey{sp("", "123")}},
+		{"1x2", MixedKey{sp("", "1"), sp("x", "2")}},
+		{"12", MixedKey{sp("", "12")}},
+		{"1\x002", MixedKey{sp("", "1"), sp("\x00", "2")}},
+		{"1\u00a02", MixedKey{sp("", "1"), sp("\u00a0", "2")}},
+		{"1 2 ", MixedKey{sp("", "1"), sp(" ", "2"), sp(" ", "")}},
+		{"x0", MixedKey{sp("x", "0")}},
+		{"file999999999999999999999.png", MixedKey{
+			sp("file", "999999999999999999999"), sp(".png", ""),
+		}},
 	}
-	opt := cmp.AllowUnexported(nspan{})
+	opt := cmp.AllowUnexported(Span{})
 	for _, test := range tests {
 		got := ParseMixed(test.input)
 		if diff := cmp.Diff(test.want, got, opt); diff != "" {
@@ -61,6 +223,513 @@ func TestParseMixed(t *testing.T) {
 	}
 }
 
+func TestParseMixedInto(t *testing.T) {
+	var buf MixedKey
+	opt := cmp.AllowUnexported(Span{})
+	for _, s := range []string{
+		"alpha25bravo-3", "", "101 dalmatians", "x", "a1b2c3d4e5", "007",
+	} {
+		buf = ParseMixedInto(s, buf)
+		if diff := cmp.Diff(ParseMixed(s), buf, cmpopts.EquateEmpty(), opt); diff != "" {
+			t.Errorf("ParseMixedInto(%q): (-want, +got):\n%s", s, diff)
+		}
+	}
+}
+
+func TestParseMixedStrict(t *testing.T) {
+	opt := cmp.AllowUnexported(Span{})
+	for _, s := range []string{"", "alpha25bravo-3", "café2", "file１０"} {
+		got, err := ParseMixedStrict(s)
+		if err != nil {
+			t.Errorf("ParseMixedStrict(%q): unexpected error: %v", s, err)
+		} else if diff := cmp.Diff(ParseMixed(s), got, opt); diff != "" {
+			t.Errorf("ParseMixedStrict(%q): (-want, +got):\n%s", s, diff)
+		}
+	}
+
+	tests := []struct {
+		input, want string
+	}{
+		{"\xff", "invalid UTF-8 at offset 0"},
+		{"a1\xff", "invalid UTF-8 at offset 2"},
+		{"é\xc3", "invalid UTF-8 at offset 2"},
+		{"x\xe2\x82", "invalid UTF-8 at offset 1"},
+	}
+	for _, test := range tests {
+		got, err := ParseMixedStrict(test.input)
+		if err == nil {
+			t.Errorf("ParseMixedStrict(%q): got %v, want error", test.input, got)
+		} else if err.Error() != test.want {
+			t.Errorf("ParseMixedStrict(%q): got error %q, want %q", test.input, err, test.want)
+		}
+	}
+
+	// Invalid bytes are kept intact in the non-digit runs.
+	want := MixedKey{sp("a\xe2", "1"), sp("\x82b", "2")}
+	for _, o := range []*Options{nil, {UnicodeDigits: true}} {
+		if diff := cmp.Diff(want, o.Parse("a\xe21\x82b2"), opt); diff != "" {
+			t.Errorf("Parse (UnicodeDigits=%v): (-want, +got):\n%s", o.unicodeDigits(), diff)
+		}
+	}
+}
+
+func TestKeysFor(t *testing.T) {
+	if got := KeysFor(nil); len(got) != 0 {
+		t.Errorf("KeysFor(nil): got %v, want empty", got)
+	}
+	input := []string{"alpha25bravo-3", "", "101 dalmatians", "x"}
+	want := []MixedKey{
+		{sp("alpha", "25"), sp("bravo-", "3")},
+		nil,
+		{sp("", "101"), sp(" dalmatians", "")},
+		{sp("x", "")},
+	}
+	if diff := cmp.Diff(want, KeysFor(input), cmp.AllowUnexported(Span{})); diff != "" {
+		t.Errorf("KeysFor(%q): (-want, +got):\n%s", input, diff)
+	}
+}
+
+func TestReconstruct(t *testing.T) {
+	tests := []struct {
+		key  MixedKey
+		want string
+	}{
+		{nil, ""},
+		{ParseMixed("foo"), "foo"},
+		{ParseMixed("file007.txt"), "file7.txt"},
+		{ParseMixed("x0"), "x0"},
+		{ParseMixed("x00"), "x0"},
+		{ParseMixed("alpha25bravo-3"), "alpha25bravo-3"},
+		{ParseMixedSigned("t-007"), "t-7"},
+		{ParseMixedSigned("t+7"), "t7"},
+		{ParseMixedFloat("v1.50x"), "v1.5x"},
+		{(&Options{Hex: true}).Parse("node0x1f"), "node31"},
+		{(&Options{Dates: true}).Parse("2023-1-5"), "20230105"},
+		{(&Options{FoldCase: true}).Parse("File2"), "file2"},
+		{(&Options{Attached: "%"}).Parse("load050%"), "load50%"},
+		{(&Options{SplitExtension: true}).Parse("img02.png"), "img2.png"},
+	}
+	for _, test := range tests {
+		if got := test.key.Reconstruct(); got != test.want {
+			t.Errorf("Reconstruct(%v): got %q, want %q", test.key, got, test.want)
+		}
+	}
+
+	// Equal keys have the same reconstruction, which parses to an equal key.
+	for _, s := range []string{"xyzzy01", "xyzzy1", "a1b002c", "101 dalmatians"} {
+		key := ParseMixed(s)
+		if got := ParseMixed(key.Reconstruct()); !got.Equal(key) {
+			t.Errorf("ParseMixed(%q.Reconstruct()): got %v, want %v", s, got, key)
+		}
+	}
+}
+
+func TestNumericRuns(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"abc", 0},
+		{"0", 1},
+		{"x0", 1},
+		{"101 dalmatians", 1},
+		{"alpha25bravo-3", 2},
+		{"1.2.3", 3},
+		{"a1b2c3d", 3},
+	}
+	for _, test := range tests {
+		if got := NumericRuns(test.input); got != test.want {
+			t.Errorf("NumericRuns(%q): got %d, want %d", test.input, got, test.want)
+		}
+		if got := ParseMixed(test.input).NumericRuns(); got != test.want {
+			t.Errorf("ParseMixed(%q).NumericRuns(): got %d, want %d", test.input, got, test.want)
+		}
+	}
+	if got := ParseMixedFloat("v1.25x3").NumericRuns(); got != 2 {
+		t.Errorf("ParseMixedFloat(v1.25x3).NumericRuns(): got %d, want 2", got)
+	}
+}
+
+func TestCountSpans(t *testing.T) {
+	for _, s := range []string{
+		"", "x", "1", "x1", "1x", "12x34", "a1b2c3", "a1b2c3d", "file-10.png",
+	} {
+		if got, want := countSpans(s), len(ParseMixed(s)); got != want {
+			t.Errorf("countSpans(%q): got %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestDigitFree(t *testing.T) {
+	input := []string{"pear", "Apple", "apple", "", "fig", "apple pie", "Éclair", "banana"}
+
+	// The keyed sorter of a Keyer does not take the digit-free path.
+	want := copyStrings(input)
+	sort.Sort(NewKeyer(want).ByMixedKey())
+
+	got := copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	slices.SortFunc(got, Compare)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SortFunc(Compare): (-want, +got):\n%s", diff)
+	}
+
+	// A single string with digits disables the fast path, without changing
+	// the order of the others.
+	input = append(input, "fig2")
+	want = copyStrings(input)
+	sort.Sort(NewKeyer(want).ByMixedKey())
+	got = copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey with digits: (-want, +got):\n%s", diff)
+	}
+
+	// Options that modify the runs disable the fast path.
+	got = []string{"b", "A", "a", "B"}
+	sort.Sort((&Options{FoldCase: true}).ByMixedKey(got))
+	if diff := cmp.Diff([]string{"A", "a", "B", "b"}, got); diff != "" {
+		t.Errorf("ByMixedKey with FoldCase: (-want, +got):\n%s", diff)
+	}
+	if got := (&Options{FoldCase: true}).Compare("B", "a"); got != 1 {
+		t.Errorf("Compare(B, a) with FoldCase: got %d, want 1", got)
+	}
+
+	// With UnicodeDigits, non-ASCII digits are digits.
+	got = []string{"x１０", "x９"}
+	sort.Sort((&Options{UnicodeDigits: true}).ByMixedKey(got))
+	if diff := cmp.Diff([]string{"x９", "x１０"}, got); diff != "" {
+		t.Errorf("ByMixedKey with UnicodeDigits: (-want, +got):\n%s", diff)
+	}
+}
+
+func BenchmarkDigitFree(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	input := make([]string, 10000)
+	for i := range input {
+		w := make([]byte, 4+r.Intn(12))
+		for j := range w {
+			w[j] = byte('a' + r.Intn(26))
+		}
+		input[i] = string(w)
+	}
+	run := func(name string, sortFunc func([]string)) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]string, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(buf, input)
+				sortFunc(buf)
+			}
+		})
+	}
+	run("ByMixedKey", func(ss []string) { sort.Sort(ByMixedKey(ss)) })
+	run("Keyer", func(ss []string) { sort.Sort(NewKeyer(ss).ByMixedKey()) })
+	run("SortFunc", func(ss []string) { slices.SortFunc(ss, Compare) })
+	run("sort.Strings", sort.Strings)
+}
+
+func BenchmarkParseMixed(b *testing.B) {
+	input := benchInput(1000)
+	b.Run("ParseMixed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, s := range input {
+				ParseMixed(s)
+			}
+		}
+	})
+	b.Run("ParseMixedInto", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf MixedKey
+		for i := 0; i < b.N; i++ {
+			for _, s := range input {
+				buf = ParseMixedInto(s, buf)
+			}
+		}
+	})
+}
+
+func TestParseMixedSigned(t *testing.T) {
+	tests := []struct {
+		input string
+		want  MixedKey
+	}{
+		{"", nil},
+		{"a-b", MixedKey{sp("a-b", "")}},
+		{"temp-", MixedKey{sp("temp-", "")}},
+		{"temp3", MixedKey{sp("temp", "3")}},
+		{"temp-5", MixedKey{sp("temp", "-5")}},
+		{"-10", MixedKey{sp("", "-10")}},
+		{"x-0", MixedKey{sp("x", "0")}},
+		{"a--5", MixedKey{sp("a-", "-5")}},
+		{"1-2", MixedKey{sp("", "1"), sp("-", "2")}},
+		{"t-1 t-2", MixedKey{sp("t", "-1"), sp(" t", "-2")}},
+		{"gain+5", MixedKey{sp("gain", "5")}},
+		{"+10", MixedKey{sp("", "10")}},
+		{"gain+", MixedKey{sp("gain+", "")}},
+		{"a+b", MixedKey{sp("a+b", "")}},
+		{"a+-5", MixedKey{sp("a+", "-5")}},
+		{"a-+5", MixedKey{sp("a-", "5")}},
+		{"1+2", MixedKey{sp("", "1"), sp("+", "2")}},
+		{"x+0", MixedKey{sp("x", "0")}},
+	}
+	opt := cmp.AllowUnexported(Span{})
+	for _, test := range tests {
+		got := ParseMixedSigned(test.input)
+		if diff := cmp.Diff(test.want, got, opt); diff != "" {
+			t.Errorf("ParseMixedSigned(%q): (-want, +got):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestMixedKeyString(t *testing.T) {
+	tests := []struct {
+		key  MixedKey
+		want string
+	}{
+		{nil, "()"},
+		{MixedKey{}, "()"},
+		{ParseMixed("foo"), `("foo")`},
+		{ParseMixed("foo0"), `("foo", 0)`},
+		{ParseMixed("007"), `("", 7)`},
+		{ParseMixed("alpha25bravo-3"), `("alpha", 25) ("bravo-", 3)`},
+		{ParseMixed("101 dalmatians"), `("", 101) (" dalmatians")`},
+		{ParseMixed("1\t2"), `("", 1) ("\t", 2)`},
+		{ParseMixedSigned("temp-10"), `("temp", -10)`},
+		{ParseMixedFloat("v0.50"), `("v", 0.5)`},
+		{ParseMixedFloat("sample2.5g"), `("sample", 2.5) ("g")`},
+
+		// Keys with equal runs and values, differing in other fields.
+		{(&Options{Attached: "%"}).Parse("load50%"), `("load", 50@"%")`},
+		{(&Options{Attached: "%"}).Parse("load50"), `("load", 50)`},
+		{(&Options{SplitExtension: true}).Parse("img2.png"), `("img", 2) *(".png")`},
+		{ParseMixed("img2.png"), `("img", 2) (".png")`},
+		{(&Options{PreRelease: "-"}).Parse("1-rc2"), `("", 1) ~("-rc", 2)`},
+		{ParseMixed("1-rc2"), `("", 1) ("-rc", 2)`},
+	}
+	for _, test := range tests {
+		if got := test.key.String(); got != test.want {
+			t.Errorf("String(%#v): got %s, want %s", test.key, got, test.want)
+		}
+	}
+
+	// Keys that are not equal have different renderings.
+	pairs := [][2]MixedKey{
+		{(&Options{Attached: "%"}).Parse("load50%"), ParseMixed("load50")},
+		{(&Options{SplitExtension: true}).Parse("a1.txt"), ParseMixed("a1.txt")},
+		{(&Options{PreRelease: "-"}).Parse("1-rc"), ParseMixed("1-rc")},
+	}
+	for _, p := range pairs {
+		if p[0].Equal(p[1]) {
+			t.Errorf("Keys %#v and %#v are unexpectedly equal", p[0], p[1])
+		} else if p[0].String() == p[1].String() {
+			t.Errorf("Keys %#v and %#v both render as %s", p[0], p[1], p[0].String())
+		}
+	}
+}
+
+func TestParseMixedFloat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  MixedKey
+	}{
+		{"", nil},
+		{"v1.2", MixedKey{sp("v", "1.2")}},
+		{"v1.10", MixedKey{sp("v", "1.1")}},
+		{"v01.00", MixedKey{sp("v", "1")}},
+		{"sample2.5g", MixedKey{sp("sample", "2.5"), sp("g", "")}},
+		{"5.", MixedKey{sp("", "5"), sp(".", "")}},
+		{".5", MixedKey{sp(".", "5")}},
+		{"1.2.3", MixedKey{sp("", "1.2"), sp(".", "3")}},
+		{"1..2", MixedKey{sp("", "1"), sp("..", "2")}},
+		{"a.b", MixedKey{sp("a.b", "")}},
+	}
+	opt := cmp.AllowUnexported(Span{})
+	for _, test := range tests {
+		got := ParseMixedFloat(test.input)
+		if diff := cmp.Diff(test.want, got, opt); diff != "" {
+			t.Errorf("ParseMixedFloat(%q): (-want, +got):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestSpanAccessors(t *testing.T) {
+	type span struct {
+		Text, Value string
+		HasValue    bool
+		Unit        string
+	}
+	tests := []struct {
+		key  MixedKey
+		want []span
+	}{
+		{nil, nil},
+		{ParseMixed("foo"), []span{{"foo", "", false, ""}}},
+		{ParseMixed("x0"), []span{{"x", "0", true, ""}}},
+		{ParseMixed("alpha25bravo-3"), []span{{"alpha", "25", true, ""}, {"bravo-", "3", true, ""}}},
+		{ParseMixed("101 dalmatians"), []span{{"", "101", true, ""}, {" dalmatians", "", false, ""}}},
+		{ParseMixedSigned("t-007"), []span{{"t", "-7", true, ""}}},
+		{ParseMixedFloat("v1.50x"), []span{{"v", "1.5", true, ""}, {"x", "", false, ""}}},
+		{(&Options{Attached: "%"}).Parse("x5%"), []span{{"x", "5", true, "%"}}},
+	}
+	for _, test := range tests {
+		var got []span
+		for _, s := range test.key {
+			got = append(got, span{s.Text(), s.Value(), s.HasValue(), s.Unit()})
+		}
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Spans of %v: (-want, +got):\n%s", test.key, diff)
+		}
+	}
+}
+
+func TestSpanCompare(t *testing.T) {
+	tests := []struct {
+		a, b Span
+		want int
+	}{
+		{sp("", ""), sp("", ""), 0},
+		{sp("x", "2"), sp("x", "2"), 0},
+		{sp("x", "2"), sp("x", "10"), -1}, // equal text, different value
+		{sp("x", "10"), sp("x", "9"), 1},  // equal text, different value
+		{sp("x", ""), sp("x", "0"), -1},   // no value is less than zero
+		{sp("x", "-5"), sp("x", "3"), -1}, // signed values
+		{sp("x", "1.5"), sp("x", "1.25"), 1},
+		{sp("a", "10"), sp("b", "2"), -1}, // different text
+		{sp("b", "1"), sp("a", "99"), 1},  // different text
+		{sp("a", ""), sp("ab", ""), -1},   // text prefix
+		{sp("file", "1"), sp("File", "1"), 1},
+	}
+	for _, test := range tests {
+		if got := test.a.Compare(test.b); got != test.want {
+			t.Errorf("Compare(%v, %v): got %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := test.b.Compare(test.a); got != -test.want {
+			t.Errorf("Compare(%v, %v): got %d, want %d", test.b, test.a, got, -test.want)
+		}
+	}
+
+	// Spans taken from parsed keys compare as their keys do.
+	ka, kb := ParseMixed("xyzzy01"), ParseMixed("xyzzy1")
+	if got := ka[0].Compare(kb[0]); got != 0 {
+		t.Errorf("Compare(%v, %v): got %d, want 0", ka[0], kb[0], got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"a", "", 1},
+		{"foo", "foo", 0},
+		{"foo", "bar", 1},
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file10", "file10", 0},
+		{"2 apples", "10 apples", -1},
+		{"alpha 10 bravo", "alpha 10 charlie", -1},
+
+		// Digit runs of any length compare by value.
+		{"file9", "file10", -1},
+		{"file99999999999999999999", "file100000000000000000000", -1},
+		{"file18446744073709551616", "file18446744073709551615", 1},
+		{"x" + strings.Repeat("9", 100), "x1" + strings.Repeat("0", 100), -1},
+		{"x" + strings.Repeat("0", 100) + "5", "x5", -1},
+
+		// Values separated by text are never merged.
+		{"1 2 3", "123", -1},
+		{"1x2", "12", -1},
+		{"1 23", "12 3", -1},
+
+		// Equal keys are ordered lexicographically.
+		{"echo001", "echo1", -1},
+		{"echo1", "echo01", 1},
+		{"xyzzy01", "xyzzy01", 0},
+	}
+	for _, test := range tests {
+		if got := Compare(test.a, test.b); got != test.want {
+			t.Errorf("Compare(%q, %q): got %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCompareNoAlloc(t *testing.T) {
+	input := append(benchInput(100),
+		"", "a", "1", "01", "a1", "a01", "a1b", "a1b2", "a10", "1a", "a 1", "é2", "é10",
+		"x"+strings.Repeat("0", 30)+"5", "x"+strings.Repeat("9", 30),
+	)
+	for _, a := range input {
+		for _, b := range input {
+			if got, want := CompareNoAlloc(a, b), Compare(a, b); got != want {
+				t.Errorf("CompareNoAlloc(%q, %q): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() {
+		CompareNoAlloc("file-10-part2.txt", "file-10-part10.txt")
+	}); n != 0 {
+		t.Errorf("CompareNoAlloc: got %v allocations, want 0", n)
+	}
+}
+
+func TestEqualMixed(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", "", true},
+		{"echo1", "echo1", true},
+		{"echo1", "echo01", true},
+		{"a0b", "a000b", true},
+		{"echo1", "echo2", false},
+		{"echo1", "Echo1", false},
+		{"echo1", "echo1 ", false},
+		{"echo", "echo0", false},
+		{"1 2", "12", false},
+	}
+	for _, test := range tests {
+		if got := EqualMixed(test.a, test.b); got != test.want {
+			t.Errorf("EqualMixed(%q, %q): got %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+
+	input := append(benchInput(100), "", "a", "a1", "a01", "a001b", "a1b", "1", "01")
+	for _, a := range input {
+		for _, b := range input {
+			if got, want := EqualMixed(a, b), ParseMixed(a).Equal(ParseMixed(b)); got != want {
+				t.Errorf("EqualMixed(%q, %q): got %v, want %v", a, b, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCompareNoAlloc(b *testing.B) {
+	input := benchInput(1000)
+	b.Run("Compare", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Compare(input[i%len(input)], input[(i+1)%len(input)])
+		}
+	})
+	b.Run("CompareNoAlloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			CompareNoAlloc(input[i%len(input)], input[(i+1)%len(input)])
+		}
+	})
+}
+
 func TestByMixedKey(t *testing.T) {
 	// The input slice must have the expected order.
 	input := []string{
@@ -115,6 +784,200 @@ func TestByMixedKey(t *testing.T) {
 	}
 }
 
+func TestCompareSortFunc(t *testing.T) {
+	input := []string{"b10", "a2", "b1", "a10", "b01", "a1", "b1"}
+	want := copyStrings(input)
+	sort.Sort(ByMixedKey(want))
+
+	got := copyStrings(input)
+	slices.SortFunc(got, Compare)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SortFunc: (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	slices.SortFunc(got, CompareFunc)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SortFunc(CompareFunc): (-want, +got):\n%s", diff)
+	}
+	for _, a := range input {
+		for _, b := range input {
+			if got, want := CompareFunc(a, b), Compare(a, b); got != want {
+				t.Errorf("CompareFunc(%q, %q): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Elements that compare equal are identical strings, so a stable sort
+	// produces the same order.
+	type item struct {
+		S string
+		N int
+	}
+	var items []item
+	for i, s := range input {
+		items = append(items, item{s, i})
+	}
+	slices.SortStableFunc(items, func(a, b item) int { return Compare(a.S, b.S) })
+	for i, v := range items {
+		if v.S != want[i] {
+			t.Errorf("SortStableFunc: item %d is %q, want %q", i, v.S, want[i])
+		}
+		if i > 0 && v.S == items[i-1].S && v.N < items[i-1].N {
+			t.Errorf("SortStableFunc: items %d and %d out of input order", i-1, i)
+		}
+	}
+}
+
+func TestStableByMixedKey(t *testing.T) {
+	input := []string{"x01", "b", "x1", "a", "x001", "x1", "a"}
+
+	got := copyStrings(input)
+	sort.Stable(StableByMixedKey(got))
+	want := []string{"a", "a", "b", "x01", "x1", "x001", "x1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StableByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	// ByMixedKey orders the same strings by value, regardless of position.
+	got = copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	want = []string{"a", "a", "b", "x001", "x01", "x1", "x1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFieldOf(t *testing.T) {
+	tests := []struct {
+		input, sep string
+		field      int
+		want       string
+	}{
+		{"", ",", 0, ""},
+		{"", "", 0, ""},
+		{"a,b,c", ",", 0, "a"},
+		{"a,b,c", ",", 2, "c"},
+		{"a,b,c", ",", 3, ""},
+		{"a,b,c", ",", -1, ""},
+		{"a,,c", ",", 1, ""},
+		{"a::b::c", "::", 1, "b"},
+		{"  a \t b  c ", "", 1, "b"},
+		{"  a \t b  c ", "", 3, ""},
+	}
+	for _, test := range tests {
+		if got := fieldOf(test.input, test.sep, test.field); got != test.want {
+			t.Errorf("fieldOf(%q, %q, %d): got %q, want %q", test.input, test.sep, test.field, got, test.want)
+		}
+	}
+}
+
+func TestByMixedKeyField(t *testing.T) {
+	input := []string{"a,item10,y", "c", "b,item2,x", "d,item2,w", "e,,z"}
+	sort.Sort(ByMixedKeyField(input, ",", 1))
+	want := []string{"c", "e,,z", "b,item2,x", "d,item2,w", "a,item10,y"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyField: (-want, +got):\n%s", diff)
+	}
+
+	input = []string{"GET  /page10 200", "GET /page9 404", "POST /page9 200"}
+	sort.Sort(ByMixedKeyField(input, "", 1))
+	want = []string{"GET /page9 404", "POST /page9 200", "GET  /page10 200"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyField (whitespace): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyIgnoring(t *testing.T) {
+	input := []string{"Item#10", "Item2", "Item 10", "Item #2", "Item-1"}
+	sort.Sort(ByMixedKeyIgnoring(input, " #"))
+	want := []string{"Item #2", "Item2", "Item 10", "Item#10", "Item-1"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyIgnoring: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeys(t *testing.T) {
+	split := func(s string) []string { return strings.Split(s, " - ") }
+	input := []string{"b1 - y", "a10 - x10", "a2 - x10", "a10 - x2", "a10", "a10 - x02", "", "a2 - x10 - z"}
+	sort.Sort(ByMixedKeys(input, split))
+	want := []string{"", "a2 - x10", "a2 - x10 - z", "a10", "a10 - x02", "a10 - x2", "a10 - x10", "b1 - y"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeys: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByNumericKey(t *testing.T) {
+	input := []string{"X12Y", "B12C3", "none", "A12B", "A2", "", "12-1", "v1.2.10", "v1.2.9"}
+	sort.Sort(ByNumericKey(input))
+	want := []string{"", "none", "v1.2.9", "v1.2.10", "A2", "A12B", "X12Y", "12-1", "B12C3"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByNumericKey: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyFunc(t *testing.T) {
+	type item struct {
+		Name string
+		ID   int
+	}
+	input := []item{
+		{"file10.png", 1},
+		{"file2.png", 2},
+		{"file01.png", 3},
+		{"file1.png", 4},
+		{"afile", 5},
+	}
+	sort.Sort(ByMixedKeyFunc(input, func(v item) string { return v.Name }))
+
+	want := []item{
+		{"afile", 5},
+		{"file01.png", 3},
+		{"file1.png", 4},
+		{"file2.png", 2},
+		{"file10.png", 1},
+	}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyFunc: (-want, +got):\n%s", diff)
+	}
+}
+
+type label struct {
+	name string
+	rev  int
+}
+
+func (v label) String() string { return fmt.Sprintf("%s-r%d", v.name, v.rev) }
+
+func TestByMixedKeyStringer(t *testing.T) {
+	input := []label{{"b", 10}, {"b", 2}, {"a", 3}, {"b", 1}}
+	sort.Sort(ByMixedKeyStringer(input))
+	want := []label{{"a", 3}, {"b", 1}, {"b", 2}, {"b", 10}}
+	if diff := cmp.Diff(want, input, cmp.AllowUnexported(label{})); diff != "" {
+		t.Errorf("ByMixedKeyStringer: (-want, +got):\n%s", diff)
+	}
+}
+
+// sp constructs a Span with the given run and value, for testing. If num is
+// empty the span has no value. A leading "-" on num marks the value as
+// negative, and digits after a "." are the fractional part.
+func sp(run, num string) Span {
+	if num == "" {
+		return Span{run: run}
+	}
+	num, frac, _ := strings.Cut(num, ".")
+	return Span{
+		run:    run,
+		num:    trimZeros(strings.TrimPrefix(num, "-")),
+		frac:   frac,
+		neg:    strings.HasPrefix(num, "-"),
+		hasNum: true,
+	}
+}
+
+// withUnit returns a copy of s with the given unit, for testing.
+func (n Span) withUnit(unit string) Span { n.unit = unit; return n }
+
 func copyStrings(ss []string) []string {
 	cp := make([]string, len(ss))
 	copy(cp, ss)
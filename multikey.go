@@ -0,0 +1,103 @@
+package stringsort
+
+import "sort"
+
+// A MultiKey orders values of type T by several string fields in turn, each
+// compared by mixed key with its own options. The zero value is ready for
+// use, and orders all values as equal until fields are added.
+//
+// For example, to sort tracks by artist without regard to case, then by album,
+// then by track number:
+//
+//	var m stringsort.MultiKey[Track]
+//	m.Field(func(t Track) string { return t.Artist }, &stringsort.Options{FoldCase: true}).
+//		Field(func(t Track) string { return t.Album }, nil).
+//		Field(func(t Track) string { return strconv.Itoa(t.Number) }, nil)
+//	sort.Sort(m.ByMixedKey(tracks))
+//
+// Values whose fields all have equal keys are ordered by the function set by
+// TieBreak, if any, and then by comparing their fields in turn as the Compare
+// method of the options for each field does, so that the order does not
+// depend on the initial order of the values.
+type MultiKey[T any] struct {
+	fields []multiField[T]
+	tie    func(a, b T) int
+}
+
+// multiField is a field of a MultiKey.
+type multiField[T any] struct {
+	key  func(T) string
+	opts *Options
+}
+
+// Field adds a field to m, after any fields previously added. The key
+// function returns the value of the field for each value, which is parsed and
+// compared according to opts. A nil opts uses the default options. Field
+// returns m to allow calls to be chained.
+func (m *MultiKey[T]) Field(key func(T) string, opts *Options) *MultiKey[T] {
+	m.fields = append(m.fields, multiField[T]{key: key, opts: opts})
+	return m
+}
+
+// TieBreak sets the function used to order values whose fields all have
+// equal keys. It should return a negative, zero, or positive value if a
+// should sort before, the same as, or after b. TieBreak returns m to allow
+// calls to be chained.
+func (m *MultiKey[T]) TieBreak(tie func(a, b T) int) *MultiKey[T] {
+	m.tie = tie
+	return m
+}
+
+// ByMixedKey returns a sorter that orders xs non-decreasing by the fields of
+// m. The field functions are called once per element, and the keys are
+// precomputed at the point of construction.
+func (m *MultiKey[T]) ByMixedKey(xs []T) sort.Interface {
+	b := byMultiKey[T]{xs: xs, m: m, ss: make([][]string, len(xs)), keys: make([][]MixedKey, len(xs))}
+	for i, x := range xs {
+		b.ss[i] = make([]string, len(m.fields))
+		b.keys[i] = make([]MixedKey, len(m.fields))
+		for j, f := range m.fields {
+			b.ss[i][j] = f.key(x)
+			b.keys[i][j] = f.opts.Parse(b.ss[i][j])
+		}
+	}
+	return b
+}
+
+// byMultiKey implements sort.Interface for values ordered by a MultiKey.
+type byMultiKey[T any] struct {
+	xs   []T          // the original slice to be sorted
+	m    *MultiKey[T] // the fields to compare
+	ss   [][]string   // the field strings of each element of xs
+	keys [][]MixedKey // the field keys of each element of xs
+}
+
+func (b byMultiKey[T]) Len() int { return len(b.xs) }
+
+func (b byMultiKey[T]) Less(i, j int) bool {
+	for f, fld := range b.m.fields {
+		if c := fld.opts.compareMixed(b.keys[i][f], b.keys[j][f]); c != 0 {
+			if fld.opts.descending() {
+				return c > 0
+			}
+			return c < 0
+		}
+	}
+	if tie := b.m.tie; tie != nil {
+		if c := tie(b.xs[i], b.xs[j]); c != 0 {
+			return c < 0
+		}
+	}
+	for f, fld := range b.m.fields {
+		if c := fld.opts.compareStrings(b.ss[i][f], b.ss[j][f], b.keys[i][f], b.keys[j][f]); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+func (b byMultiKey[T]) Swap(i, j int) {
+	b.xs[i], b.xs[j] = b.xs[j], b.xs[i]
+	b.ss[i], b.ss[j] = b.ss[j], b.ss[i]
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+}
@@ -0,0 +1,81 @@
+package stringsort
+
+import (
+	"cmp"
+	"sort"
+	"strconv"
+	"testing"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+type track struct {
+	Artist, Album string
+	Number, ID    int
+}
+
+func TestMultiKey(t *testing.T) {
+	input := []track{
+		{"beta", "Vol 10", 1, 1},
+		{"Alpha", "Vol 2", 10, 2},
+		{"alpha", "Vol 2", 2, 3},
+		{"Beta", "Vol 9", 3, 4},
+		{"alpha", "Vol 10", 1, 5},
+		{"ALPHA", "Vol 2", 2, 6},
+	}
+	var m MultiKey[track]
+	m.Field(func(t track) string { return t.Artist }, &Options{FoldCase: true}).
+		Field(func(t track) string { return t.Album }, nil).
+		Field(func(t track) string { return strconv.Itoa(t.Number) }, nil)
+
+	// Without a tie-breaker, ties are broken by the fields.
+	got := append([]track(nil), input...)
+	sort.Sort(m.ByMixedKey(got))
+	want := []track{
+		{"ALPHA", "Vol 2", 2, 6},
+		{"alpha", "Vol 2", 2, 3},
+		{"Alpha", "Vol 2", 10, 2},
+		{"alpha", "Vol 10", 1, 5},
+		{"Beta", "Vol 9", 3, 4},
+		{"beta", "Vol 10", 1, 1},
+	}
+	if diff := gocmp.Diff(want, got); diff != "" {
+		t.Errorf("MultiKey: (-want, +got):\n%s", diff)
+	}
+
+	// A tie-breaker takes precedence over the fields.
+	m.TieBreak(func(a, b track) int { return cmp.Compare(a.ID, b.ID) })
+	got = append([]track(nil), input...)
+	sort.Sort(m.ByMixedKey(got))
+	want[0], want[1] = want[1], want[0]
+	if diff := gocmp.Diff(want, got); diff != "" {
+		t.Errorf("MultiKey with TieBreak: (-want, +got):\n%s", diff)
+	}
+
+	// A descending field reverses only that field.
+	var d MultiKey[track]
+	d.Field(func(t track) string { return t.Album }, &Options{Descending: true}).
+		Field(func(t track) string { return strconv.Itoa(t.Number) }, nil).
+		TieBreak(func(a, b track) int { return cmp.Compare(a.ID, b.ID) })
+	got = append([]track(nil), input...)
+	sort.Sort(d.ByMixedKey(got))
+	var gotIDs []int
+	for _, v := range got {
+		gotIDs = append(gotIDs, v.ID)
+	}
+	wantIDs := []int{1, 5, 4, 3, 6, 2}
+	if diff := gocmp.Diff(wantIDs, gotIDs); diff != "" {
+		t.Errorf("MultiKey descending: (-want, +got):\n%s", diff)
+	}
+
+	// With no fields, the order is decided by the tie-breaker alone.
+	var z MultiKey[track]
+	z.TieBreak(func(a, b track) int { return cmp.Compare(a.ID, b.ID) })
+	got = append([]track(nil), input...)
+	sort.Sort(z.ByMixedKey(got))
+	for i, v := range got {
+		if v.ID != i+1 {
+			t.Errorf("MultiKey with no fields: element %d has ID %d, want %d", i, v.ID, i+1)
+		}
+	}
+}
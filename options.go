@@ -0,0 +1,948 @@
+package stringsort
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/bidi"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Options control how strings are parsed into mixed keys. A nil *Options is
+// ready for use, and provides the same behavior as the package-level
+// functions ParseMixed, Compare, and ByMixedKey.
+type Options struct {
+	// If true, only the first line of each string, up to but not including
+	// the first "\n", is parsed, so that for example "item2\nnotes" <
+	// "item10\nabc". A string without a newline is parsed whole. Ties are
+	// broken using the whole strings.
+	FirstLine bool
+
+	// If not empty, this prefix is removed from each string before it is
+	// parsed, so that for example with the prefix "2024/invoices/",
+	// "2024/invoices/INV-2" < "2024/invoices/INV-10" by the keys of "INV-2"
+	// and "INV-10". A string that does not have the prefix is parsed
+	// unchanged. Ties are broken using the original strings.
+	TrimPrefix string
+
+	// If not nil, this function is applied to each string, after FirstLine
+	// and TrimPrefix, before it is parsed. It must be deterministic, returning
+	// the same output for the same input. Ties are broken using the original
+	// strings, not the results of Transform.
+	Transform func(string) string
+
+	// If true, each string is converted to the Unicode normalization form
+	// given by NormalForm before it is parsed, after applying FirstLine,
+	// TrimPrefix, and Transform, so that canonically equivalent strings, such
+	// as "é" written precomposed (U+00E9) or as "e" followed by a combining
+	// accent (U+0301), have equal keys. Ties are broken using the original
+	// strings.
+	Normalize bool
+
+	// The normalization form applied if Normalize is true. The default is
+	// NFC. With a compatibility form (NFKC or NFKD), compatibility digits such
+	// as "①" and "１" are normalized to ASCII digits, and so have values.
+	NormalForm norm.Form
+
+	// If true, fold the case of the non-digit runs of each key, so that for
+	// example "Track2" and "track2" have equal keys. Folding uses Unicode
+	// simple case folding, so "Ä" and "ä" are also equal.
+	FoldCase bool
+
+	// If true, each run of consecutive whitespace in the non-digit runs of a
+	// key is replaced by a single space (U+0020), so that for example "file
+	// 2" and "file  2" have equal keys. Whitespace is as defined by
+	// unicode.IsSpace: '\t', '\n', '\v', '\f', '\r', ' ', U+0085 (NEL),
+	// U+00A0 (NBSP), and the other characters of category Z.
+	CollapseSpace bool
+
+	// If true, a file extension at the end of each string, beginning with
+	// the last ".", is split from the rest of the string, and strings are
+	// compared first by the key of the base name and then by the extension,
+	// so that "img2.png" < "img10.jpeg" < "img10.png" < "img10a.gif". The
+	// extension is compared as text, after the non-digit runs of the base.
+	// A "." that begins the string or follows a "/", as in ".bashrc", does
+	// not begin an extension, nor does a "." at the end of the string, or one
+	// followed by a "/". Ties are broken using the whole strings.
+	SplitExtension bool
+
+	// If true, the key of a string with exactly one value is rearranged so
+	// that the value comes first, followed by the text before the value and
+	// then the text after it, so that such strings are ordered primarily by
+	// their values, for example "XYZ-00007-B" < "ABC-00042-X" < "ABC-00042-Y"
+	// < "AB-100". The key of a string with no values or several values is
+	// unchanged. Since a rearranged key begins with a value, strings with one
+	// value are ordered among strings with several values that begin with a
+	// value by the usual rules, so "7-1" < "7-2" < "ABC-7" < "ABC-8".
+	NumberFirst bool
+
+	// If true, the key of a string with at least one value is rearranged so
+	// that its first value comes first, followed by the text before that
+	// value and then the rest of the key, so that such strings are ordered
+	// primarily by their first values regardless of the text before them.
+	// For example, "01 - Intro" < "Bonus 2 - Remix" < "03 - Finale". The key
+	// of a string with no values is unchanged, and since its first span has
+	// no value, such a string sorts after any string with a value unless it
+	// is empty. If FirstValue is set, NumberFirst has no effect.
+	FirstValue bool
+
+	// How to treat a suffix of letters following the last value of a string,
+	// such as the revision letter of the part number "A100B". By default, the
+	// suffix is an ordinary non-digit run, compared as text. Otherwise, a
+	// suffix of one to three ASCII letters (with UpperRevisions, uppercase
+	// letters only) is a revision, and is compared as a number in which "A"
+	// is 1, "Z" is 26, "AA" is 27, and so on, so that "A100" < "A100A" <
+	// "A100B" < "A100Z" < "A100AA" < "A101". With FoldRevisions, letters are
+	// compared without regard to case, so "A100b" and "A100B" have equal
+	// keys. A revision is a span with an empty run, whose value is the
+	// number of the revision, so it is less than any suffix that is not a
+	// revision, and it is reported as a value by the Value method of the
+	// span. The suffix must end the string, or the base name if
+	// SplitExtension is set, and must directly follow the digits of the
+	// value.
+	Revisions RevisionMode
+
+	// If not empty, a suffix of a string beginning with a rune of this
+	// string, directly following the digits of a value and followed by a
+	// letter, marks a pre-release, which sorts before the same string without
+	// the suffix. For example, with "-" the strings "1-a" < "1" < "1.0-rc" <
+	// "1.0" < "2.3-beta" < "2.3-rc1" < "2.3". Only the first such suffix of a
+	// string is a pre-release, and its text is otherwise compared as usual,
+	// so the pre-releases of a string are ordered among themselves by mixed
+	// key. A pre-release sorts before any other continuation of the string,
+	// including an extension split by SplitExtension, so "1.0-rc" < "1.0.1"
+	// and "1-rc.txt" < "1.txt". Since the suffix must begin with a letter,
+	// dates such as "2024-01-05" and ranges such as "1-2" are not affected.
+	PreRelease string
+
+	// If not empty, each run of consecutive runes from this string in the
+	// non-digit runs of a key is replaced by the first rune of the run, so
+	// that for example with "_-. " the strings "a__2" and "a_2" have equal
+	// keys, as do "a_-2" and "a_2". Runs that begin with different separators,
+	// such as "a-2" and "a_2", remain distinct.
+	CollapseSeparators string
+
+	// If not empty, the runes of this string are removed from the non-digit
+	// runs of a key before they are compared, so that for example with " #"
+	// the strings "Item #2" and "Item2" have equal keys. Runes are removed
+	// only from non-digit runs, so digits are never removed from values, and
+	// removing runes does not join the digits on either side of them into a
+	// single value. Runes are removed before any other changes to the runs.
+	Ignore string
+
+	// If true, remove diacritical marks from the non-digit runs of a key, so
+	// that for example "café2" and "cafe2" have equal keys. Each run is
+	// decomposed into canonical form (NFD), nonspacing marks (category Mn)
+	// are removed, and the result is recomposed (NFC). Marks that are
+	// decomposed are removed, so "ø" (which has no decomposition) is not
+	// folded to "o".
+	FoldAccents bool
+
+	// If true, the non-digit runs of a key are compared in visual rather than
+	// logical order, as determined by the Unicode Bidirectional Algorithm, so
+	// that right-to-left text in a run is compared in the order in which it
+	// is displayed. Digit runs are still compared by value, in their usual
+	// positions. Each run is reordered independently of the rest of the
+	// string, as a paragraph whose direction is that of its first strong
+	// character, and right-to-left text within it is reversed, with brackets
+	// mirrored. This is an approximation: Explicit embeddings and isolates
+	// are not supported, only two levels of nesting are distinguished, and
+	// the ordering of the rest of the string, including where digits appear
+	// when displayed, does not affect the order of the run. Reordering is
+	// applied after all other changes to the runs.
+	Bidi bool
+
+	// If true, a "-" or "+" immediately before a run of digits is treated as
+	// the sign of the value rather than as part of the preceding non-digit
+	// run, so that "temp-10" < "temp-5" < "temp3" < "temp+5" < "temp10". A
+	// value with a "+" sign is equal to the same value without one. A sign
+	// that is not followed by a digit, or that follows a digit (as in "1-2"
+	// or "1+2"), remains part of the run.
+	Signed bool
+
+	// If not empty, a rune of this string immediately before the digits of a
+	// value, such as a currency symbol, belongs to the value rather than to
+	// the preceding non-digit run, and is ignored in comparison. With Signed,
+	// a sign may precede the prefix. That is, a value has the form
+	//
+	//	[sign] [prefix] digits
+	//
+	// where the sign is "-" or "+" (only if Signed is true) and the prefix is
+	// a single rune of ValuePrefix. For example, with ValuePrefix "$€" and
+	// Signed, "bal-$100" < "bal-$20" < "bal$20" < "bal€100", and "bal$20"
+	// and "bal20" have equal keys. Without Signed, the "-" remains part of
+	// the run, so "bal-$20" < "bal-$100". A prefix rune that is not
+	// immediately followed by a digit remains part of the run, as does a sign
+	// after the prefix, so "$-5" has the run "$" and the value -5 (with
+	// Signed). The prefix is recognized before any attached runes (see
+	// Attached).
+	ValuePrefix string
+
+	// If true, a run of digits followed by "." and another run of digits is
+	// treated as a single decimal value, so that "v1.10" < "v1.2" < "v1.25".
+	// A "." is part of a value only if it has digits on both sides, so the
+	// dot in "5." or ".5" remains part of the adjacent run. A second dot ends
+	// the value, so "1.2.3" has the values 1.2 and 3.
+	Float bool
+
+	// If true, a value followed by an exponent is treated as a number in
+	// scientific notation, so that "sample2e2" (200) < "sample1e3" (1000).
+	// The accepted grammar is
+	//
+	//	value    = mantissa exponent
+	//	mantissa = digits [ "." digits ]   (the fraction only with Float)
+	//	exponent = ( "e" | "E" ) [ "+" | "-" ] digits
+	//
+	// The magnitude of the exponent must be at most 999. If the characters
+	// following the mantissa do not form an exponent, such as in "1e", "1e+",
+	// or "1e1000", the mantissa is an ordinary value and the "e" begins the
+	// following run. Values are compared exactly, not as floating-point, so
+	// "1e2" and "100" have equal keys. With DigitOrder, only the digits of
+	// the integer part of the mantissa are counted.
+	Exponent bool
+
+	// If true, each value is rounded to the nearest float64, so that values
+	// are compared approximately, as by a program that parses them as
+	// floating-point numbers. Values that round to the same float64 are equal,
+	// and values greater than the largest float64 (about 1.8e308) are equal to
+	// it, rather than overflowing. Integers are exact up to 2^53
+	// (9007199254740992), and any value with at most 15 significant digits is
+	// unchanged, but larger integers and longer fractions may lose precision,
+	// so that for example "id9007199254740993" and "id9007199254740992" have
+	// equal keys. Otherwise, values are compared exactly regardless of their
+	// magnitude or precision.
+	RoundFloat64 bool
+
+	// If true, a "0x" or "0X" prefix followed by one or more hexadecimal
+	// digits is treated as a single value, so that "node0x2" < "node0x1f".
+	// The value extends to the last consecutive hexadecimal digit. A "0x"
+	// that is not followed by a hexadecimal digit is parsed as the value 0
+	// followed by a run beginning with "x".
+	Hex bool
+
+	// If true, a run of digits with a leading zero, such as "010", is treated
+	// as an octal value (here, 8) if all its digits are octal. Other runs,
+	// including runs with a fractional part (see Float) and runs containing
+	// the digits 8 or 9, are decimal. Note that with DigitOrder, runs with
+	// equal values are ordered by the number of digits in the original
+	// string, regardless of base, so with ShorterFirst "8" < "010".
+	Octal bool
+
+	// If true, any Unicode decimal digit (category Nd) is treated as a digit,
+	// not only the ASCII digits "0" to "9". For example, "ｆｉｌｅ１０" has the
+	// value 10. Non-ASCII digits are converted to their ASCII equivalents in
+	// the key, so "file１０" and "file10" have equal keys. When this option
+	// is set, the input is scanned by runes rather than by bytes, so that
+	// runs are split only at rune boundaries.
+	UnicodeDigits bool
+
+	// If not nil, this function defines which runes are digits, and their
+	// values, in place of the default (ASCII digits) or UnicodeDigits. It
+	// should report whether r is a digit, and if so its value from 0 to 9;
+	// runes with other values are not digits. For example, to recognize
+	// Devanagari digits as well as ASCII digits:
+	//
+	//	func(r rune) (int, bool) {
+	//		if r >= '०' && r <= '९' {
+	//			return int(r - '०'), true
+	//		}
+	//		return int(r - '0'), r >= '0' && r <= '9'
+	//	}
+	//
+	// The digits are converted to ASCII in the key, as for UnicodeDigits. The
+	// input is scanned by runes. DigitFunc does not affect the digits of
+	// dates (see Dates) or hexadecimal values (see Hex), which are ASCII.
+	DigitFunc func(r rune) (value int, isDigit bool)
+
+	// If not nil, this function classifies each rune of the input as Text,
+	// Digit, or Ignore, in place of the default (see ClassifyASCII),
+	// UnicodeDigits, and DigitFunc. A rune classified as Digit is a digit if
+	// it is a decimal digit (category Nd), with its usual value, and is
+	// otherwise treated as Text. Runes classified as Ignore are removed from
+	// the non-digit runs of the key, as described for the Ignore field. For
+	// example, to treat "_" as ignorable, so that "file_10" and "file10" have
+	// equal keys:
+	//
+	//	func(r rune) stringsort.Class {
+	//		if r == '_' {
+	//			return stringsort.Ignore
+	//		}
+	//		return stringsort.ClassifyASCII(r)
+	//	}
+	//
+	// The input is scanned by runes. Classify does not affect the digits of
+	// dates (see Dates) or hexadecimal values (see Hex), which are ASCII.
+	Classify func(r rune) Class
+
+	// If true, a value immediately followed by one of the duration units "s"
+	// (seconds), "m" (minutes), "h" (hours), or "d" (days) is converted to
+	// seconds, so that durations compare by length, for example "task90s" <
+	// "task5m" < "task2h". The unit is consumed as part of the value, so
+	// "task90s" and "task90" have equal keys. A unit letter that is followed
+	// by another letter, as in "5min" or "5ms", is not a unit, and other
+	// suffixes are not recognized, so the value is a plain number. With
+	// Float, fractions are converted exactly, so "1.5h" and "90m" have equal
+	// keys.
+	Durations bool
+
+	// If true, a calendar date of the form YYYY-MM-DD or YYYY/MM/DD is treated
+	// as a single value, so that dates compare chronologically even if the
+	// month and day are not zero-padded, for example "backup-2023-1-5" <
+	// "backup-2023-01-10". The year must have four digits, and the month and
+	// day one or two. If a string of this form is not a valid date, such as
+	// "2023-02-30", its digit runs are parsed separately as usual.
+	Dates bool
+
+	// If nonzero, this rune is treated as a digit group separator when it
+	// occurs between two digits, so that "1,000" has the value 1000 when the
+	// separator is ','. A separator that is not both preceded and followed by
+	// a digit remains part of the adjacent run, as in "a, b" or "1,". Other
+	// common choices are '.', ' ', and '_'. Separators are recognized only in
+	// the integer part of a value, so with the '.' separator and Float, a "."
+	// between digits is always a separator.
+	GroupSeparator rune
+
+	// If positive, the maximum number of digits in a single value. A value
+	// ends after MaxDigits digits, and parsing resumes as if the next digit
+	// followed a non-digit, so that for example with MaxDigits 3, "x12345"
+	// has the key ("x", 123) ("", 45). Leading zeros count toward the limit,
+	// as do the digits of hexadecimal values (see Hex), but group separators
+	// (see GroupSeparator) do not. Any hexadecimal digits past the limit are
+	// not part of a value unless they are decimal digits, since they lack a
+	// "0x" prefix. If a value is split, a fraction (see Float) belongs to its
+	// last piece. This bounds the work and memory required to parse and
+	// compare adversarial inputs with very long runs of digits. By default
+	// there is no limit.
+	MaxDigits int
+
+	// If not empty, the runes of this string are attached to an adjacent
+	// value, rather than being part of a non-digit run. A sequence of attached
+	// runes immediately before or after a value is its unit, and values are
+	// compared first by unit, then by value. For example, with Attached "%$€",
+	// "load50%" has the run "load" and the value 50 with unit "%", and
+	// "cost$5" < "cost$100" < "cost€3". A unit is compared only if the runs
+	// preceding the values are equal.
+	Attached string
+
+	// The order of digit runs whose values are equal. By default, such runs
+	// are equal, and so for example "echo01" and "echo1" have equal keys.
+	// Otherwise, runs with equal values are ordered by the number of digits
+	// in the original string, so that "echo1" < "echo01" < "echo001" (with
+	// ShorterFirst) or "echo001" < "echo01" < "echo1" (with LongerFirst).
+	// With DigitsLexical, such runs are ordered by the lexicographic order of
+	// their original digits, so that "echo001" < "echo01" < "echo1".
+	// For decimal values, only the digits of the integer part are counted.
+	//
+	// The digit order applies to each span independently of the rest of the
+	// key, so for example with ShorterFirst "a1z" < "a01b", but "a1b9" <
+	// "a01b1" because the digits of the first span are compared before the
+	// value of the second.
+	DigitOrder DigitOrder
+
+	// If true, values are compared first by the number of digits in the
+	// original string, and then by value, so that a longer digit run is
+	// greater than a shorter one regardless of their values. For example,
+	// "id1" < "id999" < "id0001" < "id1000". As with DigitOrder, only the
+	// digits of the integer part of a decimal value are counted, and leading
+	// zeros count. Runs of the same length are ordered by value, and then by
+	// the DigitOrder, if any. If Signed is set, the length rule applies only
+	// between values of the same sign: Negative values are less than
+	// non-negative ones, and a longer negative run is less than a shorter
+	// one, so "t-0001" < "t-999" < "t-1" < "t1" < "t999" < "t0001".
+	LengthFirst bool
+
+	// If positive, digit runs of at most this many digits are fixed-width
+	// fields, which are compared as text right-aligned in a field of this
+	// width, as by legacy systems that sort by the padded representation of
+	// a field. A run with fewer digits than the width is padded on the left
+	// with spaces, which sort before any digit, so padding is significant:
+	// with a width of 3, "A7" < "A9" < "A07" < "A99" < "A007" < "A010". By
+	// default, and in contrast, "A7" and "A007" have equal keys. Runs longer
+	// than the width overflow the field, and sort after all runs that fit,
+	// ordered by value, so "A999" < "A1000" < "A01001". As with LengthFirst,
+	// only the digits of the integer part of a decimal value are counted.
+	// Runs with equal representations are ordered by value, and then by the
+	// DigitOrder, if any. If Signed is set, the width applies only between
+	// values of the same sign, and is reversed for negative values, as for
+	// LengthFirst, so "A-007" < "A-7" < "A7" < "A007".
+	FixedWidth int
+
+	// If not nil, use this collator to compare the non-digit runs of keys,
+	// instead of comparing them lexicographically. Digit runs are still
+	// compared by value. Ties are broken by using the collator to compare the
+	// original strings, and then by their lexicographic order, so that strings
+	// with equal keys, for example due to FoldCase, are ordered consistently
+	// with the collator rather than by their bytes.
+	//
+	// A collate.Collator is not safe for concurrent use, so neither are the
+	// Compare method or sorters of an Options with a collator.
+	Collator *collate.Collator
+
+	// If not nil, this function is called to compare strings whose keys are
+	// equal, and should return a negative, zero, or positive value if a
+	// should sort before, the same as, or after b. It is not called for
+	// strings with unequal keys. If it returns zero, ties are broken as if it
+	// were nil: By the Collator, if any, and then lexicographically.
+	TieBreak func(a, b string) int
+
+	// If true, order strings by descending mixed key. Strings whose keys are
+	// equal are still ordered by ascending lexicographic order, so the
+	// relative order of strings with equal keys does not depend on the
+	// direction of the sort.
+	Descending bool
+
+	// If true, a span with a value is less than a span without a value whose
+	// non-digit run is equal, so that "item1" < "item". By default, a span
+	// without a value is less, so "item" < "item1". Either way, runs are
+	// compared first, so "item" < "items1" and "item1" < "items".
+	ValuesFirst bool
+
+	// If true, spans whose non-digit runs are equal are ordered by descending
+	// value, while the runs themselves are still compared in ascending order,
+	// so that "buildA10" < "buildA2" < "buildB3". A span without a value is
+	// still less than a span with a value, so "buildB" < "buildB3". Unlike
+	// Descending, this affects only the comparison of values.
+	ValuesDescending bool
+}
+
+// A DigitOrder specifies how to order digit runs whose values are equal.
+type DigitOrder int
+
+const (
+	DigitsEqual   DigitOrder = iota // runs with equal values are equal (default)
+	ShorterFirst                    // runs with fewer digits sort first
+	LongerFirst                     // runs with more digits sort first
+	DigitsLexical                   // runs are ordered by their original digits
+)
+
+// A RevisionMode specifies how to treat a suffix of letters following the
+// last value of a string.
+type RevisionMode int
+
+const (
+	NoRevisions    RevisionMode = iota // suffixes are text (default)
+	UpperRevisions                     // uppercase suffixes are revisions
+	FoldRevisions                      // suffixes of either case are revisions
+)
+
+// A Class is the classification of a rune by Options.Classify.
+type Class int
+
+const (
+	Text   Class = iota // the rune is part of a non-digit run
+	Digit               // the rune is a digit
+	Ignore              // the rune is removed from non-digit runs
+)
+
+// ClassifyASCII classifies the ASCII digits "0" to "9" as Digit, and all other
+// runes as Text. This is the default classification.
+func ClassifyASCII(r rune) Class {
+	if r >= '0' && r <= '9' {
+		return Digit
+	}
+	return Text
+}
+
+// ClassifyUnicode classifies the Unicode decimal digits (category Nd) as
+// Digit, and all other runes as Text, as for Options.UnicodeDigits.
+func ClassifyUnicode(r rune) Class {
+	if unicode.IsDigit(r) {
+		return Digit
+	}
+	return Text
+}
+
+// Parse parses s into a MixedKey according to the options.
+func (o *Options) Parse(s string) MixedKey {
+	if o == nil {
+		return parseMixed(s, o, nil)
+	}
+	if o.FirstLine {
+		s, _, _ = strings.Cut(s, "\n")
+	}
+	if o.TrimPrefix != "" {
+		s = strings.TrimPrefix(s, o.TrimPrefix)
+	}
+	if o.Transform != nil {
+		s = o.Transform(s)
+	}
+	if o.Normalize {
+		s = o.NormalForm.String(s)
+	}
+	var ext string
+	if o.SplitExtension {
+		s, ext = splitExtension(s)
+	}
+	key := parseMixed(s, o, nil)
+	if o.Revisions != NoRevisions {
+		key = revision(key, o.Revisions)
+	}
+	if o.PreRelease != "" {
+		markPreRelease(key, o.PreRelease)
+	}
+	if o.FirstValue {
+		key = firstValue(key)
+	} else if o.NumberFirst {
+		key = numberFirst(key)
+	}
+	if ext != "" {
+		key = append(key, Span{run: ext, ext: true})
+	}
+	for i, sp := range key {
+		if o.Ignore != "" {
+			sp.run = removeRunes(sp.run, o.Ignore)
+		}
+		if o.Classify != nil {
+			sp.run = removeIgnored(sp.run, o.Classify)
+		}
+		if o.CollapseSpace {
+			sp.run = collapseSpace(sp.run)
+		}
+		if o.CollapseSeparators != "" {
+			sp.run = collapseRuns(sp.run, o.CollapseSeparators)
+		}
+		if o.FoldAccents {
+			sp.run = foldAccents(sp.run)
+		}
+		if o.FoldCase {
+			sp.run = foldCase(sp.run)
+		}
+		if o.Bidi {
+			sp.run = visualOrder(sp.run)
+		}
+		key[i] = sp
+	}
+	return key
+}
+
+// ParseStrict parses s into a MixedKey according to the options, as Parse,
+// but reports an error without parsing s if it is not valid UTF-8.
+func (o *Options) ParseStrict(s string) (MixedKey, error) {
+	if !utf8.ValidString(s) {
+		for i := 0; i < len(s); {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				return nil, fmt.Errorf("invalid UTF-8 at offset %d", i)
+			}
+			i += size
+		}
+	}
+	return o.Parse(s), nil
+}
+
+// Compare compares a and b by mixed key according to the options. Ties on key
+// order are broken as described for Compare.
+func (o *Options) Compare(a, b string) int {
+	if a == b {
+		return 0
+	} else if o.plainText() && !o.hasDigits(a) && !o.hasDigits(b) {
+		return strings.Compare(a, b)
+	}
+	return o.compareStrings(a, b, o.Parse(a), o.Parse(b))
+}
+
+// ByMixedKey returns a sorter that orders ss non-decreasing by mixed key
+// according to the options. Ties on key order are broken as described for
+// the ByMixedKey function.
+func (o *Options) ByMixedKey(ss []string) sort.Interface {
+	if o.plainText() && !o.anyDigits(ss) {
+		// The keys of strings without digits are in the same order as the
+		// strings themselves, so we need not construct them.
+		return sort.StringSlice(ss)
+	}
+	return o.byMixedKey(ss)
+}
+
+func (o *Options) byMixedKey(ss []string) byMixedKey {
+	kp := byMixedKey{
+		ss:   ss,
+		keys: make([]MixedKey, len(ss)),
+		opts: o,
+	}
+	for i, s := range ss {
+		kp.keys[i] = o.Parse(s)
+	}
+	return kp
+}
+
+func (o *Options) signed() bool        { return o != nil && o.Signed }
+func (o *Options) float() bool         { return o != nil && o.Float }
+func (o *Options) exponent() bool      { return o != nil && o.Exponent }
+func (o *Options) roundFloat64() bool  { return o != nil && o.RoundFloat64 }
+func (o *Options) hex() bool           { return o != nil && o.Hex }
+func (o *Options) octal() bool         { return o != nil && o.Octal }
+func (o *Options) unicodeDigits() bool { return o != nil && o.UnicodeDigits }
+func (o *Options) dates() bool         { return o != nil && o.Dates }
+func (o *Options) durations() bool     { return o != nil && o.Durations }
+func (o *Options) descending() bool    { return o != nil && o.Descending }
+
+func (o *Options) valuesFirst() bool      { return o != nil && o.ValuesFirst }
+func (o *Options) valuesDescending() bool { return o != nil && o.ValuesDescending }
+func (o *Options) lengthFirst() bool      { return o != nil && o.LengthFirst }
+
+// recordDigits reports whether keys must record the original digits of their
+// values, as needed to compare them according to the options.
+func (o *Options) recordDigits() bool {
+	return o.digitOrder() != DigitsEqual || o.lengthFirst() || o.fixedWidth() > 0
+}
+
+// plainText reports whether the options order strings without digits in
+// their lexicographic order, as is the case if the strings and the runs of
+// their keys are compared without modification.
+func (o *Options) plainText() bool {
+	return o == nil || !(o.FirstLine || o.SplitExtension || o.TrimPrefix != "" || o.Transform != nil ||
+		o.Normalize || o.FoldCase || o.CollapseSpace || o.CollapseSeparators != "" || o.Ignore != "" ||
+		o.Classify != nil || o.FoldAccents || o.Bidi || o.Collator != nil || o.Descending)
+}
+
+// hasDigits reports whether s contains a digit according to the options.
+func (o *Options) hasDigits(s string) bool {
+	for i := 0; i < len(s); {
+		_, size, ok := o.scanDigit(s, i)
+		if ok {
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// anyDigits reports whether any of the strings of ss contains a digit
+// according to the options.
+func (o *Options) anyDigits(ss []string) bool {
+	for _, s := range ss {
+		if o.hasDigits(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Options) collator() *collate.Collator {
+	if o == nil {
+		return nil
+	}
+	return o.Collator
+}
+
+func (o *Options) tieBreak() func(a, b string) int {
+	if o == nil {
+		return nil
+	}
+	return o.TieBreak
+}
+
+func (o *Options) valuePrefix() string {
+	if o == nil {
+		return ""
+	}
+	return o.ValuePrefix
+}
+
+func (o *Options) attached() string {
+	if o == nil {
+		return ""
+	}
+	return o.Attached
+}
+
+func (o *Options) maxDigits() int {
+	if o == nil {
+		return 0
+	}
+	return o.MaxDigits
+}
+
+func (o *Options) classify() func(rune) Class {
+	if o == nil {
+		return nil
+	}
+	return o.Classify
+}
+
+func (o *Options) digitFunc() func(rune) (int, bool) {
+	if o == nil {
+		return nil
+	}
+	return o.DigitFunc
+}
+
+func (o *Options) groupSeparator() rune {
+	if o == nil {
+		return 0
+	}
+	return o.GroupSeparator
+}
+
+func (o *Options) digitOrder() DigitOrder {
+	if o == nil {
+		return DigitsEqual
+	}
+	return o.DigitOrder
+}
+
+func (o *Options) fixedWidth() int {
+	if o == nil {
+		return 0
+	}
+	return o.FixedWidth
+}
+
+// collapseSpace returns a copy of s in which each run of whitespace is
+// replaced by a single space. If s does not contain any such runs other than
+// single spaces, it is returned unmodified.
+func collapseSpace(s string) string {
+	var sb strings.Builder
+	last := 0     // offset of the first byte not yet copied to sb
+	inSpace := -1 // offset of the start of the current space run, or -1
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsSpace(r) {
+			if inSpace < 0 {
+				inSpace = i
+			}
+		} else if inSpace >= 0 {
+			if s[inSpace:i] != " " {
+				sb.WriteString(s[last:inSpace])
+				sb.WriteByte(' ')
+				last = i
+			}
+			inSpace = -1
+		}
+		i += size
+	}
+	if inSpace >= 0 && s[inSpace:] != " " {
+		sb.WriteString(s[last:inSpace])
+		sb.WriteByte(' ')
+		last = len(s)
+	}
+	if last == 0 {
+		return s // no changes
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// numberFirst returns key rearranged as described for Options.NumberFirst.
+func numberFirst(key MixedKey) MixedKey {
+	if len(key) == 0 || len(key) > 2 || !key[0].hasNum || (len(key) == 2 && key[1].hasNum) {
+		return key // not exactly one value
+	}
+	var suffix string
+	if len(key) == 2 {
+		suffix = key[1].run
+	}
+	value := key[0]
+	value.run = ""
+	return MixedKey{value, {run: key[0].run}, {run: suffix}}
+}
+
+// firstValue returns key rearranged as described for Options.FirstValue.
+func firstValue(key MixedKey) MixedKey {
+	if len(key) == 0 || !key[0].hasNum {
+		return key // no values
+	}
+	value := key[0]
+	value.run = ""
+	out := make(MixedKey, 0, len(key)+1)
+	return append(append(out, value, Span{run: key[0].run}), key[1:]...)
+}
+
+// maxRevision is the maximum number of letters in a revision suffix.
+const maxRevision = 3
+
+// revision returns key with a revision suffix converted to a value, as
+// described for Options.Revisions.
+func revision(key MixedKey, mode RevisionMode) MixedKey {
+	n := len(key)
+	if n < 2 || key[n-1].hasNum || !key[n-2].hasNum || key[n-2].unit != "" {
+		return key // no suffix following a value
+	}
+	suffix := key[n-1].run
+	if suffix == "" || len(suffix) > maxRevision {
+		return key
+	}
+	var v uint64
+	for i := 0; i < len(suffix); i++ {
+		ch := suffix[i]
+		if mode == FoldRevisions && ch >= 'a' && ch <= 'z' {
+			ch -= 'a' - 'A'
+		}
+		if ch < 'A' || ch > 'Z' {
+			return key // not a revision
+		}
+		v = v*26 + uint64(ch-'A'+1)
+	}
+	key[n-1] = Span{num: strconv.FormatUint(v, 10), hasNum: true}
+	return key
+}
+
+// markPreRelease marks the first span of key that begins a pre-release
+// suffix, as described for Options.PreRelease.
+func markPreRelease(key MixedKey, set string) {
+	for i := 1; i < len(key); i++ {
+		if !key[i-1].hasNum || key[i-1].unit != "" {
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(key[i].run)
+		if n > 0 && strings.ContainsRune(set, r) {
+			if next, _ := utf8.DecodeRuneInString(key[i].run[n:]); unicode.IsLetter(next) {
+				key[i].pre = true
+				return
+			}
+		}
+	}
+}
+
+// splitExtension splits s into a base name and a file extension beginning
+// with the last ".", as described for Options.SplitExtension. If s has no
+// extension, it returns s, "".
+func splitExtension(s string) (base, ext string) {
+	i := strings.LastIndexByte(s, '.')
+	if i <= 0 || i == len(s)-1 || s[i-1] == '/' || strings.IndexByte(s[i:], '/') >= 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// collapseRuns returns a copy of s in which each run of consecutive runes
+// from set is replaced by the first rune of the run. If s does not contain
+// any such runs of more than one rune, it is returned unmodified.
+func collapseRuns(s, set string) string {
+	var sb strings.Builder
+	last := 0      // offset of the first byte not yet copied to sb
+	inRun := false // whether the previous rune was in set
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		isSep := strings.ContainsRune(set, r)
+		if isSep && inRun {
+			sb.WriteString(s[last:i]) // drop this rune
+			last = i + size
+		}
+		inRun = isSep
+		i += size
+	}
+	if last == 0 {
+		return s // no changes
+	}
+	sb.WriteString(s[last:])
+	return sb.String()
+}
+
+// removeRunes returns a copy of s with all the runes of set removed. If s
+// does not contain any runes from set, it is returned unmodified.
+func removeRunes(s, set string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(set, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// removeIgnored returns a copy of s with all the runes that classify reports
+// as Ignore removed. If s does not contain any such runes, it is returned
+// unmodified.
+func removeIgnored(s string, classify func(rune) Class) string {
+	return strings.Map(func(r rune) rune {
+		if classify(r) == Ignore {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// foldAccents returns a copy of s with diacritical marks removed.
+func foldAccents(s string) string {
+	if isASCII(s) {
+		return s // no marks to remove
+	}
+	// N.B. Transformers are stateful, so we cannot share one among calls.
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return out
+}
+
+// visualOrder returns the runes of s in the order in which they are
+// displayed, as described for Options.Bidi. If s does not contain any runes
+// that are displayed right-to-left, it is returned unmodified.
+func visualOrder(s string) string {
+	if isASCII(s) {
+		return s // no right-to-left runes
+	}
+	var p bidi.Paragraph
+	n, err := p.SetString(s)
+	if err != nil {
+		return s
+	}
+	// If the paragraph ends at a paragraph separator, the text before the
+	// separator and the rest of the string are reordered separately, and the
+	// separator remains in place.
+	if r, size := utf8.DecodeLastRuneInString(s[:n]); isParagraphSeparator(r) {
+		return visualOrder(s[:n-size]) + s[n-size:n] + visualOrder(s[n:])
+	}
+	o, err := p.Order()
+	if err != nil {
+		return s
+	}
+	runs := make([]string, o.NumRuns())
+	rtl := false
+	for i := range runs {
+		r := o.Run(i)
+		if r.Direction() == bidi.RightToLeft {
+			runs[i], rtl = bidi.ReverseString(r.String()), true
+		} else {
+			runs[i] = r.String()
+		}
+	}
+	if !rtl {
+		return s
+	} else if o.Direction() == bidi.RightToLeft {
+		slices.Reverse(runs) // the paragraph is displayed right-to-left
+	}
+	return strings.Join(runs, "")
+}
+
+// isParagraphSeparator reports whether r is a paragraph separator (bidi class
+// B), such as "\n".
+func isParagraphSeparator(r rune) bool {
+	props, _ := bidi.LookupRune(r)
+	return props.Class() == bidi.B
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// foldCase returns a copy of s in which each rune is replaced by a canonical
+// representative of its Unicode case-folding orbit.
+func foldCase(s string) string { return strings.Map(foldRune, s) }
+
+// foldRune returns the lower-case form of the smallest rune in the
+// case-folding orbit of r, so that all the members of the orbit map to the
+// same rune.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return unicode.ToLower(min)
+}
@@ -0,0 +1,938 @@
+package stringsort
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestOptionsParse(t *testing.T) {
+	tests := []struct {
+		opts  *Options
+		input string
+		want  MixedKey
+	}{
+		{nil, "Track10.ogg", MixedKey{sp("Track", "10"), sp(".ogg", "")}},
+		{&Options{}, "Track10.ogg", MixedKey{sp("Track", "10"), sp(".ogg", "")}},
+		{&Options{FoldCase: true}, "Track10.OGG", MixedKey{sp("track", "10"), sp(".ogg", "")}},
+		{&Options{FoldCase: true}, "ÄÖÜ 3", MixedKey{sp("äöü ", "3")}},
+		{&Options{FoldCase: true}, "K5", MixedKey{sp("k", "5")}}, // Kelvin sign
+
+		{&Options{Hex: true}, "node0x1f", MixedKey{sp("node", "31")}},
+		{&Options{Hex: true}, "node0X00FF.", MixedKey{sp("node", "255"), sp(".", "")}},
+		{&Options{Hex: true}, "0x1g", MixedKey{sp("", "1"), sp("g", "")}},
+		{&Options{Hex: true}, "0x", MixedKey{sp("", "0"), sp("x", "")}},
+		{&Options{Hex: true}, "0xz", MixedKey{sp("", "0"), sp("xz", "")}},
+		{&Options{Hex: true}, "10x5", MixedKey{sp("", "10"), sp("x", "5")}},
+		{&Options{Hex: true}, "0x0", MixedKey{sp("", "0")}},
+		{&Options{Hex: true, Signed: true}, "a-0x10", MixedKey{sp("a", "-16")}},
+
+		{&Options{Octal: true}, "x010", MixedKey{sp("x", "8")}},
+		{&Options{Octal: true}, "x10", MixedKey{sp("x", "10")}},
+		{&Options{Octal: true}, "x0", MixedKey{sp("x", "0")}},
+		{&Options{Octal: true}, "x00", MixedKey{sp("x", "0")}},
+		{&Options{Octal: true}, "x0777", MixedKey{sp("x", "511")}},
+		{&Options{Octal: true}, "x019", MixedKey{sp("x", "19")}},
+		{&Options{Octal: true, Float: true}, "x010.5", MixedKey{sp("x", "10.5")}},
+
+		{nil, "file１０", MixedKey{sp("file１０", "")}},
+		{&Options{UnicodeDigits: true}, "ｆｉｌｅ１０", MixedKey{sp("ｆｉｌｅ", "10")}},
+		{&Options{UnicodeDigits: true}, "file٣٢x", MixedKey{sp("file", "32"), sp("x", "")}},
+		{&Options{UnicodeDigits: true}, "१२3", MixedKey{sp("", "123")}},
+		{&Options{UnicodeDigits: true}, "é1ü", MixedKey{sp("é", "1"), sp("ü", "")}},
+		{&Options{UnicodeDigits: true, Signed: true}, "é-٥", MixedKey{sp("é", "-5")}},
+		{&Options{UnicodeDigits: true, Signed: true}, "٥-٥", MixedKey{sp("", "5"), sp("-", "5")}},
+		{&Options{UnicodeDigits: true, Float: true}, "v١.٥٠", MixedKey{sp("v", "1.5")}},
+
+		{&Options{GroupSeparator: ','}, "report1,000", MixedKey{sp("report", "1000")}},
+		{&Options{GroupSeparator: ','}, "1,2,345 x", MixedKey{sp("", "12345"), sp(" x", "")}},
+		{&Options{GroupSeparator: ','}, "a, b", MixedKey{sp("a, b", "")}},
+		{&Options{GroupSeparator: ','}, "1,", MixedKey{sp("", "1"), sp(",", "")}},
+		{&Options{GroupSeparator: ','}, ",1", MixedKey{sp(",", "1")}},
+		{&Options{GroupSeparator: ','}, "1,,2", MixedKey{sp("", "1"), sp(",,", "2")}},
+		{&Options{GroupSeparator: ' '}, "pop 8 000 000", MixedKey{sp("pop ", "8000000")}},
+		{&Options{GroupSeparator: '.', Float: true}, "1.000.5", MixedKey{sp("", "10005")}},
+		{&Options{GroupSeparator: ',', Float: true}, "1,000.5,5", MixedKey{sp("", "1000.5"), sp(",", "5")}},
+		{&Options{GroupSeparator: ' ', UnicodeDigits: true}, "x１ ０００", MixedKey{sp("x", "1000")}},
+
+		{&Options{Dates: true}, "backup-2023-01-05", MixedKey{sp("backup-", "20230105")}},
+		{&Options{Dates: true}, "backup-2023-1-5.tgz", MixedKey{sp("backup-", "20230105"), sp(".tgz", "")}},
+		{&Options{Dates: true}, "2024/2/29", MixedKey{sp("", "20240229")}},
+		{&Options{Dates: true}, "2023/2/29", MixedKey{sp("", "2023"), sp("/", "2"), sp("/", "29")}},
+		{&Options{Dates: true}, "2023-13-01", MixedKey{sp("", "2023"), sp("-", "13"), sp("-", "1")}},
+		{&Options{Dates: true}, "2023-01/05", MixedKey{sp("", "2023"), sp("-", "1"), sp("/", "5")}},
+		{&Options{Dates: true}, "2023-001-05", MixedKey{sp("", "2023"), sp("-", "1"), sp("-", "5")}},
+		{&Options{Dates: true}, "12023-01-05", MixedKey{sp("", "12023"), sp("-", "1"), sp("-", "5")}},
+		{&Options{Dates: true}, "2023-01-", MixedKey{sp("", "2023"), sp("-", "1"), sp("-", "")}},
+		{&Options{Dates: true, Signed: true}, "t-2023-01-05", MixedKey{sp("t-", "20230105")}},
+
+		{&Options{Attached: "%"}, "load50%", MixedKey{sp("load", "50").withUnit("%")}},
+		{&Options{Attached: "%"}, "load50% x", MixedKey{sp("load", "50").withUnit("%"), sp(" x", "")}},
+		{&Options{Attached: "$€"}, "cost$5", MixedKey{sp("cost", "5").withUnit("$")}},
+		{&Options{Attached: "$€"}, "cost €5 $", MixedKey{sp("cost ", "5").withUnit("€"), sp(" $", "")}},
+		{&Options{Attached: "$%"}, "$5%", MixedKey{sp("", "5").withUnit("$%")}},
+		{&Options{Attached: "$"}, "$$", MixedKey{sp("$$", "")}},
+		{&Options{Attached: "$", Signed: true}, "x$-5", MixedKey{sp("x", "-5").withUnit("$")}},
+
+		{&Options{TrimPrefix: "2024/"}, "2024/inv10", MixedKey{sp("inv", "10")}},
+		{&Options{TrimPrefix: "2024/"}, "2023/inv10", MixedKey{sp("", "2023"), sp("/inv", "10")}},
+		{&Options{TrimPrefix: "x", Transform: strings.ToUpper}, "xa1x", MixedKey{sp("A", "1"), sp("X", "")}},
+
+		{&Options{Exponent: true}, "sample1e3", MixedKey{sp("sample", "1000")}},
+		{&Options{Exponent: true}, "sample2E+2x", MixedKey{sp("sample", "200"), sp("x", "")}},
+		{&Options{Exponent: true}, "s25e-3", MixedKey{sp("s", "0.025")}},
+		{&Options{Exponent: true}, "s25e-1", MixedKey{sp("s", "2.5")}},
+		{&Options{Exponent: true}, "s0e5", MixedKey{sp("s", "0")}},
+		{&Options{Exponent: true}, "s007e0002", MixedKey{sp("s", "700")}},
+		{&Options{Exponent: true}, "s1e", MixedKey{sp("s", "1"), sp("e", "")}},
+		{&Options{Exponent: true}, "s1e+", MixedKey{sp("s", "1"), sp("e+", "")}},
+		{&Options{Exponent: true}, "s1ex", MixedKey{sp("s", "1"), sp("ex", "")}},
+		{&Options{Exponent: true}, "s1e1000", MixedKey{sp("s", "1"), sp("e", "1000")}},
+		{&Options{Exponent: true}, "s1e999", MixedKey{sp("s", "1"+strings.Repeat("0", 999))}},
+		{&Options{Exponent: true, Float: true}, "s1.25e1", MixedKey{sp("s", "12.5")}},
+		{&Options{Exponent: true, Float: true}, "s1.25e-2", MixedKey{sp("s", "0.0125")}},
+		{&Options{Exponent: true, Signed: true}, "t-5e-1", MixedKey{sp("t", "-0.5")}},
+		{&Options{Exponent: true}, "1.5e2", MixedKey{sp("", "1"), sp(".", "500")}},
+
+		{&Options{Normalize: true}, "cafe\u03012", MixedKey{sp("caf\u00e9", "2")}},
+		{&Options{Normalize: true, NormalForm: norm.NFD}, "caf\u00e92", MixedKey{sp("cafe\u0301", "2")}},
+		{&Options{Normalize: true, NormalForm: norm.NFKC}, "x\u2460\uff12", MixedKey{sp("x", "12")}},
+		{&Options{Normalize: true}, "x\u2460", MixedKey{sp("x\u2460", "")}},
+
+		{&Options{DigitFunc: devanagari}, "x१०", MixedKey{sp("x", "10")}},
+		{&Options{DigitFunc: devanagari}, "x१1०y", MixedKey{sp("x", "110"), sp("y", "")}},
+		{&Options{DigitFunc: devanagari}, "x००१", MixedKey{sp("x", "1")}},
+		{&Options{DigitFunc: devanagari}, "x１", MixedKey{sp("x１", "")}},
+		{&Options{DigitFunc: roman}, "v1xⅢ", MixedKey{sp("v1x", "3")}},
+		{&Options{DigitFunc: roman}, "Ⅻ", MixedKey{sp("Ⅻ", "")}},
+
+		{&Options{FirstLine: true}, "item2\nnotes 5", MixedKey{sp("item", "2")}},
+		{&Options{FirstLine: true}, "item2", MixedKey{sp("item", "2")}},
+		{&Options{FirstLine: true}, "\nitem2", nil},
+		{&Options{FirstLine: true}, "a1\r\nb", MixedKey{sp("a", "1"), sp("\r", "")}},
+
+		{&Options{MaxDigits: 3}, "x123", MixedKey{sp("x", "123")}},
+		{&Options{MaxDigits: 3}, "x1234", MixedKey{sp("x", "123"), sp("", "4")}},
+		{&Options{MaxDigits: 3}, "x12345678y", MixedKey{sp("x", "123"), sp("", "456"), sp("", "78"), sp("y", "")}},
+		{&Options{MaxDigits: 3}, "x0001", MixedKey{sp("x", "0"), sp("", "1")}},
+		{&Options{MaxDigits: 3, GroupSeparator: ','}, "1,000,000", MixedKey{sp("", "100"), sp("", "0"), sp("", "0")}},
+		{&Options{MaxDigits: 4, GroupSeparator: ','}, "1,000,000", MixedKey{sp("", "1000"), sp(",", "0")}},
+		{&Options{MaxDigits: 2, Float: true}, "v123.45", MixedKey{sp("v", "12"), sp("", "3.45")}},
+		{&Options{MaxDigits: 2, Hex: true}, "0xfff", MixedKey{sp("", "255"), sp("f", "")}},
+		{&Options{MaxDigits: 2, Hex: true}, "0xff", MixedKey{sp("", "255")}},
+		{&Options{MaxDigits: 2, Hex: true}, "0xff1", MixedKey{sp("", "255"), sp("", "1")}},
+
+		{&Options{NumberFirst: true}, "ABC-00042-X", MixedKey{sp("", "42"), sp("ABC-", ""), sp("-X", "")}},
+		{&Options{NumberFirst: true}, "ABC-42", MixedKey{sp("", "42"), sp("ABC-", ""), sp("", "")}},
+		{&Options{NumberFirst: true}, "42", MixedKey{sp("", "42"), sp("", ""), sp("", "")}},
+		{&Options{NumberFirst: true}, "ABC", MixedKey{sp("ABC", "")}},
+		{&Options{NumberFirst: true}, "A1B2", MixedKey{sp("A", "1"), sp("B", "2")}},
+		{&Options{NumberFirst: true, FoldCase: true}, "Ab1Cd", MixedKey{sp("", "1"), sp("ab", ""), sp("cd", "")}},
+		{&Options{FirstValue: true}, "12 - Song Title", MixedKey{sp("", "12"), sp("", ""), sp(" - Song Title", "")}},
+		{&Options{FirstValue: true}, "Bonus 2 - Remix", MixedKey{sp("", "2"), sp("Bonus ", ""), sp(" - Remix", "")}},
+		{&Options{FirstValue: true}, "A1B2", MixedKey{sp("", "1"), sp("A", ""), sp("B", "2")}},
+		{&Options{FirstValue: true}, "Outro", MixedKey{sp("Outro", "")}},
+		{&Options{FirstValue: true, NumberFirst: true}, "ABC-42", MixedKey{sp("", "42"), sp("ABC-", "")}},
+
+		{&Options{Durations: true}, "task90s", MixedKey{sp("task", "90")}},
+		{&Options{Durations: true}, "task5m", MixedKey{sp("task", "300")}},
+		{&Options{Durations: true}, "task2h.log", MixedKey{sp("task", "7200"), sp(".log", "")}},
+		{&Options{Durations: true}, "ttl1d", MixedKey{sp("ttl", "86400")}},
+		{&Options{Durations: true}, "t0m", MixedKey{sp("t", "0")}},
+		{&Options{Durations: true}, "t5min", MixedKey{sp("t", "5"), sp("min", "")}},
+		{&Options{Durations: true}, "t5ms", MixedKey{sp("t", "5"), sp("ms", "")}},
+		{&Options{Durations: true}, "t5x", MixedKey{sp("t", "5"), sp("x", "")}},
+		{&Options{Durations: true}, "t1h30m", MixedKey{sp("t", "3600"), sp("", "1800")}},
+		{&Options{Durations: true, Float: true}, "t1.5h", MixedKey{sp("t", "5400")}},
+		{&Options{Durations: true, Float: true}, "t0.25s", MixedKey{sp("t", "0.25")}},
+		{&Options{Durations: true, Float: true}, "t0.001m", MixedKey{sp("t", "0.06")}},
+
+		{&Options{ValuePrefix: "$€"}, "bal$100", MixedKey{sp("bal", "100")}},
+		{&Options{ValuePrefix: "$€"}, "bal-$100", MixedKey{sp("bal-", "100")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal-$100", MixedKey{sp("bal", "-100")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal+€5", MixedKey{sp("bal", "5")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "€-5", MixedKey{sp("€", "-5")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "-$5", MixedKey{sp("", "-5")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "$$5", MixedKey{sp("$", "5")}},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal$ 5$", MixedKey{sp("bal$ ", "5"), sp("$", "")}},
+		{&Options{ValuePrefix: "$"}, "1$2", MixedKey{sp("", "1"), sp("", "2")}},
+		{&Options{ValuePrefix: "$", Signed: true}, "1-$2", MixedKey{sp("", "1"), sp("-", "2")}},
+		{&Options{ValuePrefix: "$", Attached: "$"}, "x$5$", MixedKey{sp("x", "5").withUnit("$")}},
+		{&Options{Revisions: UpperRevisions}, "A100B", MixedKey{sp("A", "100"), sp("", "2")}},
+		{&Options{Revisions: UpperRevisions}, "A100AA", MixedKey{sp("A", "100"), sp("", "27")}},
+		{&Options{Revisions: UpperRevisions}, "A100ZZZ", MixedKey{sp("A", "100"), sp("", "18278")}},
+		{&Options{Revisions: UpperRevisions}, "A100ABCD", MixedKey{sp("A", "100"), sp("ABCD", "")}},
+		{&Options{Revisions: UpperRevisions}, "A100b", MixedKey{sp("A", "100"), sp("b", "")}},
+		{&Options{Revisions: UpperRevisions}, "A100-B", MixedKey{sp("A", "100"), sp("-B", "")}},
+		{&Options{Revisions: UpperRevisions}, "A100", MixedKey{sp("A", "100")}},
+		{&Options{Revisions: UpperRevisions}, "ABC", MixedKey{sp("ABC", "")}},
+		{&Options{Revisions: FoldRevisions}, "A100b", MixedKey{sp("A", "100"), sp("", "2")}},
+		{&Options{Revisions: FoldRevisions}, "A100aB", MixedKey{sp("A", "100"), sp("", "28")}},
+		{&Options{Revisions: FoldRevisions, SplitExtension: true}, "A100c.pdf", MixedKey{sp("A", "100"), sp("", "3"), {run: ".pdf", ext: true}}},
+		{&Options{RoundFloat64: true}, "id123456789012345", MixedKey{sp("id", "123456789012345")}},
+		{&Options{RoundFloat64: true}, "id9007199254740993", MixedKey{sp("id", "9007199254740992")}},
+		{&Options{RoundFloat64: true}, "x1" + strings.Repeat("0", 400), MixedKey{sp("x", strconv.FormatFloat(math.MaxFloat64, 'f', -1, 64))}},
+		{&Options{RoundFloat64: true, Float: true}, "t0.1000000000000000055511", MixedKey{sp("t", "0.1")}},
+		{&Options{RoundFloat64: true, Float: true}, "t0." + strings.Repeat("0", 400) + "1", MixedKey{sp("t", "0")}},
+		{&Options{RoundFloat64: true, Float: true, Signed: true}, "t-0." + strings.Repeat("0", 400) + "1", MixedKey{sp("t", "0")}},
+		{&Options{RoundFloat64: true, Exponent: true}, "t1e999", MixedKey{sp("t", strconv.FormatFloat(math.MaxFloat64, 'f', -1, 64))}},
+		{&Options{PreRelease: "-"}, "2.3-beta", MixedKey{sp("", "2"), sp(".", "3"), {run: "-beta", pre: true}}},
+		{&Options{PreRelease: "-"}, "1.0-rc.2-x", MixedKey{sp("", "1"), sp(".", "0"), {run: "-rc.", num: "2", hasNum: true, pre: true}, sp("-x", "")}},
+		{&Options{PreRelease: "-~"}, "v1~alpha", MixedKey{sp("v", "1"), {run: "~alpha", pre: true}}},
+		{&Options{PreRelease: "-"}, "2024-01-05", MixedKey{sp("", "2024"), sp("-", "01"), sp("-", "05")}},
+		{&Options{PreRelease: "-"}, "ver-b", MixedKey{sp("ver-b", "")}},
+		{&Options{PreRelease: "-"}, "2.3+beta", MixedKey{sp("", "2"), sp(".", "3"), sp("+beta", "")}},
+	}
+	opt := cmp.AllowUnexported(Span{})
+	for _, test := range tests {
+		got := test.opts.Parse(test.input)
+		if diff := cmp.Diff(test.want, got, opt); diff != "" {
+			t.Errorf("Parse(%q) %+v: (-want, +got):\n%s", test.input, test.opts, diff)
+		}
+	}
+}
+
+// devanagari is a DigitFunc for ASCII and Devanagari digits.
+func devanagari(r rune) (int, bool) {
+	if r >= '०' && r <= '९' {
+		return int(r - '०'), true
+	}
+	return int(r - '0'), r >= '0' && r <= '9'
+}
+
+// underscoreIgnored is a Classify function that treats "_" as ignorable, and
+// otherwise classifies runes as ClassifyASCII does.
+func underscoreIgnored(r rune) Class {
+	if r == '_' {
+		return Ignore
+	}
+	return ClassifyASCII(r)
+}
+
+func TestClassify(t *testing.T) {
+	opt := cmp.AllowUnexported(Span{})
+	inputs := []string{"", "abc", "file10", "a1b22c333", "x１０", "é٣", "0x1f"}
+	for _, s := range inputs {
+		if diff := cmp.Diff(ParseMixed(s), (&Options{Classify: ClassifyASCII}).Parse(s), opt); diff != "" {
+			t.Errorf("Parse(%q) with ClassifyASCII: (-want, +got):\n%s", s, diff)
+		}
+		uni := &Options{UnicodeDigits: true}
+		if diff := cmp.Diff(uni.Parse(s), (&Options{Classify: ClassifyUnicode}).Parse(s), opt); diff != "" {
+			t.Errorf("Parse(%q) with ClassifyUnicode: (-want, +got):\n%s", s, diff)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  MixedKey
+	}{
+		{"file_10", MixedKey{sp("file", "10")}},
+		{"__a__1__", MixedKey{sp("a", "1"), sp("", "")}},
+		{"1_000", MixedKey{sp("", "1"), sp("", "0")}},
+		{"x١٠", MixedKey{sp("x١٠", "")}},
+	}
+	o := &Options{Classify: underscoreIgnored}
+	for _, test := range tests {
+		if diff := cmp.Diff(test.want, o.Parse(test.input), opt); diff != "" {
+			t.Errorf("Parse(%q): (-want, +got):\n%s", test.input, diff)
+		}
+	}
+
+	input := []string{"file_10", "file2", "file_2", "file10", "file_1_0"}
+	sort.Sort(o.ByMixedKey(input))
+	want := []string{"file_1_0", "file2", "file_2", "file10", "file_10"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+}
+
+// roman is a DigitFunc for the Roman numerals Ⅰ to Ⅸ, and not ASCII digits.
+// The numeral Ⅻ has a value too large to be a digit.
+func roman(r rune) (int, bool) {
+	if r >= 'Ⅰ' && r <= 'Ⅻ' {
+		return int(r-'Ⅰ') + 1, true
+	}
+	return 0, false
+}
+
+func TestDigitValue(t *testing.T) {
+	// Every decimal digit must have a value consistent with its neighbors.
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if !unicode.IsDigit(r) {
+			continue
+		}
+		v := digitValue(r)
+		if v > 9 {
+			t.Errorf("digitValue(%U): got %d, want 0..9", r, v)
+		} else if v > 0 && digitValue(r-1) != v-1 {
+			t.Errorf("digitValue(%U): got %d, but previous digit is %d", r, v, digitValue(r-1))
+		}
+	}
+}
+
+func TestCollapseSpace(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{" ", " "},
+		{"a b c", "a b c"},
+		{"a  b", "a b"},
+		{"a\tb", "a b"},
+		{"  a \t\n b\u00a0", " a b "},
+		{"a\u3000\u3000b ", "a b "},
+		{"\xff  \xfe", "\xff \xfe"},
+	}
+	for _, test := range tests {
+		if got := collapseSpace(test.input); got != test.want {
+			t.Errorf("collapseSpace(%q): got %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestSplitExtension(t *testing.T) {
+	tests := []struct {
+		input, base, ext string
+	}{
+		{"", "", ""},
+		{"img2.png", "img2", ".png"},
+		{"archive.tar.gz", "archive.tar", ".gz"},
+		{"README", "README", ""},
+		{".bashrc", ".bashrc", ""},
+		{".config.old", ".config", ".old"},
+		{"dir/.bashrc", "dir/.bashrc", ""},
+		{"v1.2/notes", "v1.2/notes", ""},
+		{"trailing.", "trailing.", ""},
+		{"..", "..", ""},
+		{"a.b/c.d", "a.b/c", ".d"},
+	}
+	for _, test := range tests {
+		base, ext := splitExtension(test.input)
+		if base != test.base || ext != test.ext {
+			t.Errorf("splitExtension(%q): got (%q, %q), want (%q, %q)",
+				test.input, base, ext, test.base, test.ext)
+		}
+	}
+}
+
+func TestSplitExtensionOrder(t *testing.T) {
+	input := []string{
+		"img10.png", "img2.png", "img10a.gif", ".bashrc", "img10.jpeg", "img10", "img2",
+	}
+	sort.Sort((&Options{SplitExtension: true}).ByMixedKey(input))
+	want := []string{
+		".bashrc", "img2", "img2.png", "img10", "img10.jpeg", "img10.png", "img10a.gif",
+	}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("SplitExtension: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCollapseRuns(t *testing.T) {
+	const set = "_-. "
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{"_", "_"},
+		{"a_b", "a_b"},
+		{"a__b", "a_b"},
+		{"a_-.b", "a_b"},
+		{"-_a", "-a"},
+		{"a  b..", "a b."},
+		{"a_b--c", "a_b-c"},
+		{"ä__ö", "ä_ö"},
+	}
+	for _, test := range tests {
+		if got := collapseRuns(test.input, set); got != test.want {
+			t.Errorf("collapseRuns(%q, %q): got %q, want %q", test.input, set, got, test.want)
+		}
+	}
+}
+
+func TestByMixedKeyNormalized(t *testing.T) {
+	input := []string{"file 10", "file\t2", "file  2", "file 1", "file 2", "file\u00a02"}
+	sort.Sort(ByMixedKeyNormalized(input))
+	want := []string{"file 1", "file\t2", "file  2", "file 2", "file\u00a02", "file 10"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyNormalized: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFoldAccents(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{"cafe", "cafe"},
+		{"café", "cafe"},
+		{"cafe\u0301", "cafe"},
+		{"Ångström", "Angstrom"},
+		{"naïve façade", "naive facade"},
+		{"øre", "øre"},
+		{"日本", "日本"},
+	}
+	for _, test := range tests {
+		if got := foldAccents(test.input); got != test.want {
+			t.Errorf("foldAccents(%q): got %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestVisualOrder(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"café", "café"},
+		{"אבג", "גבא"},
+		{"abc אבג def", "abc גבא def"},
+		{"אבג abc", "abc גבא"},
+		{"(אב)", "(בא)"},
+		{"מ.", ".מ"},
+		{"x\nאבג", "x\nגבא"},
+		{"אבג\nx", "גבא\nx"},
+		{"אב\u2029גד", "בא\u2029דג"},
+	}
+	for _, test := range tests {
+		if got := visualOrder(test.input); got != test.want {
+			t.Errorf("visualOrder(%q): got %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestByMixedKeyAccents(t *testing.T) {
+	input := []string{"cafe10", "café2", "cafés", "cafe2", "cafe1", "cafd"}
+	sort.Sort(ByMixedKeyAccents(input))
+	want := []string{"cafd", "cafe1", "cafe2", "café2", "cafe10", "cafés"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyAccents: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyNFC(t *testing.T) {
+	const (
+		composed   = "caf\u00e9"  // é as a single rune
+		decomposed = "cafe\u0301" // e followed by a combining acute accent
+	)
+	input := []string{decomposed + "10", composed + "2", decomposed + "2", composed + "1"}
+
+	// Without normalization, the different forms are not adjacent.
+	cp := copyStrings(input)
+	sort.Sort(ByMixedKey(cp))
+	want := []string{decomposed + "2", decomposed + "10", composed + "1", composed + "2"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	sort.Sort(ByMixedKeyNFC(input))
+	want = []string{composed + "1", decomposed + "2", composed + "2", decomposed + "10"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyNFC: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyFold(t *testing.T) {
+	input := []string{
+		"ä1", "Ä2", "Ä10", "track1.ogg", "Track2.ogg", "track2.ogg", "TRACK10.ogg",
+	}
+	cp := copyStrings(input)
+	sort.Strings(cp)
+	if cmp.Equal(cp, input) {
+		t.Fatal("Test failed: input is already in lexicographic order")
+	}
+	sort.Sort(ByMixedKeyFold(cp))
+	want := []string{
+		"track1.ogg", "Track2.ogg", "track2.ogg", "TRACK10.ogg", "ä1", "Ä2", "Ä10",
+	}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyFold: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyCaseTie(t *testing.T) {
+	input := []string{"a10", "a2", "A2", "A1", "a1", "b1", "B01", "b01", "aB1", "Ab1"}
+
+	sort.Sort(ByMixedKeyCaseTie(input, true))
+	want := []string{"A1", "a1", "A2", "a2", "a10", "Ab1", "aB1", "B01", "b01", "b1"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyCaseTie(true): (-want, +got):\n%s", diff)
+	}
+
+	sort.Sort(ByMixedKeyCaseTie(input, false))
+	want = []string{"a1", "A1", "a2", "A2", "a10", "aB1", "Ab1", "b01", "b1", "B01"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyCaseTie(false): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyNumberFirst(t *testing.T) {
+	input := []string{"AB-100", "ABC-00042-Y", "ABC-8", "7-2", "XYZ-00007-B", "ABC-00042-X", "7-1", "ABC-7", "none"}
+
+	sort.Sort(ByMixedKeyNumberFirst(input))
+	want := []string{"7-1", "7-2", "ABC-7", "XYZ-00007-B", "ABC-8", "ABC-00042-X", "ABC-00042-Y", "AB-100", "none"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyNumberFirst: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyFirstValue(t *testing.T) {
+	input := []string{"Outro", "12 - Song Title", "03 - Finale", "Bonus 2 - Remix", "2 - Other", "01 - Intro", "Disc 1 - 10", "Disc 1 - 9", ""}
+
+	sort.Sort(ByMixedKeyFirstValue(input))
+	want := []string{"", "01 - Intro", "Disc 1 - 9", "Disc 1 - 10", "2 - Other", "Bonus 2 - Remix", "03 - Finale", "12 - Song Title", "Outro"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyFirstValue: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestRevisions(t *testing.T) {
+	input := []string{"A101", "A100AA", "A100b", "A100", "A100Z", "A100B", "A100-x", "A100A", "A100a"}
+
+	// By default, the suffix is text, so the order depends on the bytes.
+	got := copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	want := []string{"A100", "A100-x", "A100A", "A100AA", "A100B", "A100Z", "A100a", "A100b", "A101"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	sort.Sort((&Options{Revisions: UpperRevisions}).ByMixedKey(got))
+	want = []string{"A100", "A100A", "A100B", "A100Z", "A100AA", "A100-x", "A100a", "A100b", "A101"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (upper): (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	sort.Sort((&Options{Revisions: FoldRevisions}).ByMixedKey(got))
+	want = []string{"A100", "A100A", "A100a", "A100B", "A100b", "A100Z", "A100AA", "A100-x", "A101"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (fold): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFixedWidth(t *testing.T) {
+	input := []string{"A010", "A7", "A1000", "A007", "A99", "A07", "A9", "A01001", "A999"}
+
+	// By default, values are compared numerically, and padding is ignored
+	// except to break ties.
+	got := copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	want := []string{"A007", "A07", "A7", "A9", "A010", "A99", "A999", "A1000", "A01001"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	sort.Sort((&Options{FixedWidth: 3}).ByMixedKey(got))
+	want = []string{"A7", "A9", "A07", "A99", "A007", "A010", "A999", "A1000", "A01001"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (fixed width): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestPreRelease(t *testing.T) {
+	input := []string{"1.0", "1", "2.3", "1.0-rc", "2.3-beta", "1-a", "1.0.1", "2.3-rc1"}
+
+	// By default, a suffix makes the key longer, so it sorts later.
+	got := copyStrings(input)
+	sort.Sort(ByMixedKey(got))
+	want := []string{"1", "1-a", "1.0", "1.0-rc", "1.0.1", "2.3", "2.3-beta", "2.3-rc1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	got = copyStrings(input)
+	sort.Sort((&Options{PreRelease: "-"}).ByMixedKey(got))
+	want = []string{"1-a", "1", "1.0-rc", "1.0", "1.0.1", "2.3-beta", "2.3-rc1", "2.3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (pre-release): (-want, +got):\n%s", diff)
+	}
+
+	// A pre-release sorts before its release with or without an extension.
+	ext := &Options{PreRelease: "-", SplitExtension: true}
+	input = []string{"1.txt", "1-b.txt", "1", "1.md", "1-a", "1-a.md", "2-a.txt"}
+	if err := ext.CheckOrder(input); err != nil {
+		t.Errorf("CheckOrder (extension): %v", err)
+	}
+	got = copyStrings(input)
+	sort.Sort(ext.ByMixedKey(got))
+	want = []string{"1-a", "1-a.md", "1-b.txt", "1", "1.md", "1.txt", "2-a.txt"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (extension): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyDesc(t *testing.T) {
+	input := []string{"file1", "file01", "b", "file10", "file2", "a", "file001"}
+	sort.Sort(ByMixedKeyDesc(input))
+	want := []string{"file10", "file2", "file001", "file01", "file1", "b", "a"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyDesc: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyWidth(t *testing.T) {
+	input := []string{"echo1", "echo001", "echo2", "echo01", "echo0", "echo00"}
+
+	sort.Sort(ByMixedKeyWidth(input, true))
+	want := []string{"echo0", "echo00", "echo1", "echo01", "echo001", "echo2"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyWidth(true): (-want, +got):\n%s", diff)
+	}
+
+	sort.Sort(ByMixedKeyWidth(input, false))
+	want = []string{"echo00", "echo0", "echo001", "echo01", "echo1", "echo2"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyWidth(false): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyAttached(t *testing.T) {
+	input := []string{"load100%", "loader", "load5%", "load50%", "load7"}
+
+	sort.Sort(ByMixedKeyAttached(input, "%"))
+	want := []string{"load7", "load5%", "load50%", "load100%", "loader"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyAttached: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyPrefix(t *testing.T) {
+	input := []string{
+		"2024/invoices/INV-10", "INV-3", "2024/invoices/INV-2", "2024/invoices/INV-02",
+	}
+	sort.Sort(ByMixedKeyPrefix(input, "2024/invoices/"))
+	want := []string{
+		"2024/invoices/INV-02", "2024/invoices/INV-2", "INV-3", "2024/invoices/INV-10",
+	}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyPrefix: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyTransform(t *testing.T) {
+	input := []string{"ITEM20", "item10", "  Item2", "Item2", "item02"}
+	var calls int
+	sort.Sort(ByMixedKeyTransform(input, func(s string) string {
+		calls++
+		return strings.ToLower(strings.TrimSpace(s))
+	}))
+	want := []string{"  Item2", "Item2", "item02", "item10", "ITEM20"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyTransform: (-want, +got):\n%s", diff)
+	}
+	if calls != len(input) {
+		t.Errorf("ByMixedKeyTransform: transform called %d times, want %d", calls, len(input))
+	}
+}
+
+func TestByMixedKeyTrimmed(t *testing.T) {
+	input := []string{"file 2 ", "file10", "\tfile 10", "file 2", "file  2", "  file 2", "file 1\n"}
+	sort.Sort(ByMixedKeyTrimmed(input))
+	want := []string{"file10", "file 1\n", "  file 2", "file 2", "file 2 ", "\tfile 10", "file  2"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyTrimmed: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyValuesDesc(t *testing.T) {
+	input := []string{"buildB", "buildA1", "buildB3", "buildA10", "buildA2", "buildA10x"}
+
+	sort.Sort(ByMixedKeyValuesDesc(input))
+	want := []string{"buildA10", "buildA10x", "buildA2", "buildA1", "buildB", "buildB3"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyValuesDesc: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyCollator(t *testing.T) {
+	input := []string{"ål2", "zed", "al10", "ål10", "al2", "apa"}
+
+	// Without a collator, "å" sorts after "z" by byte order.
+	cp := copyStrings(input)
+	sort.Sort(ByMixedKey(cp))
+	want := []string{"al2", "al10", "apa", "zed", "ål2", "ål10"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	// In English, "å" sorts with "a".
+	cp = copyStrings(input)
+	sort.Sort(ByMixedKeyCollator(cp, collate.New(language.English)))
+	want = []string{"al2", "al10", "ål2", "ål10", "apa", "zed"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyCollator(en): (-want, +got):\n%s", diff)
+	}
+
+	// In Swedish, "å" sorts after "z".
+	cp = copyStrings(input)
+	sort.Sort(ByMixedKeyCollator(cp, collate.New(language.Swedish)))
+	want = []string{"al2", "al10", "apa", "zed", "ål2", "ål10"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyCollator(sv): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCollatorTieBreak(t *testing.T) {
+	// Folding case and accents makes the keys of these strings equal when
+	// their values are, so their order depends on how ties are broken. In
+	// byte order "E1" < "e1" < "É1" < "é1", which contradicts the collator.
+	input := []string{"É2", "e10", "é1", "E1", "e1", "É1", "e2"}
+	opts := &Options{FoldCase: true, FoldAccents: true, Collator: collate.New(language.English)}
+
+	got := copyStrings(input)
+	sort.Sort(opts.ByMixedKey(got))
+	want := []string{"e1", "E1", "é1", "É1", "e2", "É2", "e10"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	// Compare agrees with the sorted order for every pair.
+	for i, a := range want {
+		for j, b := range want {
+			if got, want := opts.Compare(a, b), compareInt(i, j); got != want {
+				t.Errorf("Compare(%q, %q): got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Without folding, the collator orders the runs themselves, and strings
+	// whose runs are equal are in the same relative order as before.
+	got = copyStrings(input)
+	sort.Sort((&Options{Collator: collate.New(language.English)}).ByMixedKey(got))
+	want = []string{"e1", "e2", "e10", "E1", "é1", "É1", "É2"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ByMixedKey (no folding): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyRoot(t *testing.T) {
+	input := []string{"Z1", "b1", "ä1", "A1", "a10", "a1", "(a)1", "_a1", "a2"}
+
+	cp := copyStrings(input)
+	sort.Sort(ByMixedKey(cp))
+	want := []string{"(a)1", "A1", "Z1", "_a1", "a1", "a2", "a10", "b1", "ä1"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKey: (-want, +got):\n%s", diff)
+	}
+
+	sort.Sort(ByMixedKeyRoot(input))
+	want = []string{"_a1", "(a)1", "a1", "a2", "a10", "A1", "ä1", "b1", "Z1"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("ByMixedKeyRoot: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestByMixedKeyTie(t *testing.T) {
+	input := []string{"x01", "x1", "x0001", "x001", "y", "x2"}
+
+	cp := copyStrings(input)
+	sort.Sort(ByMixedKeyTie(cp, nil))
+	want := []string{"x0001", "x001", "x01", "x1", "x2", "y"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyTie(nil): (-want, +got):\n%s", diff)
+	}
+
+	cp = copyStrings(input)
+	sort.Sort(ByMixedKeyTie(cp, func(a, b string) int { return len(a) - len(b) }))
+	want = []string{"x1", "x01", "x001", "x0001", "x2", "y"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyTie(len): (-want, +got):\n%s", diff)
+	}
+
+	// A tie-breaker that returns zero falls back to lexicographic order.
+	cp = copyStrings(input)
+	sort.Sort(ByMixedKeyTie(cp, func(a, b string) int { return 0 }))
+	want = []string{"x0001", "x001", "x01", "x1", "x2", "y"}
+	if diff := cmp.Diff(want, cp); diff != "" {
+		t.Errorf("ByMixedKeyTie(0): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptionsCompare(t *testing.T) {
+	fold := &Options{FoldCase: true}
+	signed := &Options{Signed: true}
+	desc := &Options{Descending: true}
+	float := &Options{Float: true}
+	tests := []struct {
+		opts *Options
+		a, b string
+		want int
+	}{
+		{nil, "a2", "A10", 1},
+		{fold, "a2", "A10", -1},
+		{fold, "A2", "a2", -1}, // tie broken without folding
+		{fold, "a2", "a2", 0},
+		{nil, "temp-10", "temp-5", 1},
+		{signed, "temp-10", "temp-5", -1},
+		{signed, "temp-5", "temp3", -1},
+		{signed, "temp-0", "temp0", -1}, // tie broken lexicographically
+		{nil, "v1.10", "v1.2", 1},
+		{float, "v1.10", "v1.2", -1},
+		{float, "v1.2", "v1.25", -1},
+		{&Options{Float: true, Signed: true}, "t-1.5", "t-1.25", -1},
+		{signed, "gain+5", "gain+10", -1},
+		{signed, "gain-5", "gain+5", -1},
+		{signed, "gain+5", "gain5", -1}, // equal keys
+		{signed, "gain+", "gain+5", 1},
+		{signed, "gain+10", "gain9", 1},
+		{&Options{Hex: true}, "node0x2", "node0x1f", -1},
+		{&Options{Hex: true}, "node0x1f", "node30", 1},
+		{&Options{Hex: true}, "0x" + strings.Repeat("f", 40), "0x1" + strings.Repeat("0", 40), -1},
+		{&Options{UnicodeDigits: true}, "file２", "file１０", -1},
+		{&Options{UnicodeDigits: true}, "file１０", "file10", 1},
+		{&Options{DigitOrder: ShorterFirst}, "a01b", "a1c", 1},
+		{&Options{DigitOrder: ShorterFirst}, "a01b", "a1b", 1},
+		{&Options{DigitOrder: LongerFirst}, "a01b", "a1b", -1},
+		{&Options{DigitOrder: LongerFirst, Hex: true}, "a0x0f", "a0xf", -1},
+		{&Options{DigitOrder: DigitsLexical}, "a01b", "a1b", -1},
+		{&Options{DigitOrder: DigitsLexical}, "a001", "a01", -1},
+		{&Options{DigitOrder: DigitsLexical}, "a1", "a01", 1},
+		{&Options{DigitOrder: DigitsLexical, Hex: true}, "a0xF", "a0xf", -1},
+		{&Options{DigitOrder: ShorterFirst}, "a1z", "a01b", -1},
+		{&Options{DigitOrder: ShorterFirst}, "a1b9", "a01b1", -1},
+		{&Options{DigitOrder: LongerFirst}, "a1b9", "a01b1", 1},
+		{&Options{LengthFirst: true}, "id999", "id1000", -1},
+		{&Options{LengthFirst: true}, "id999", "id0001", -1},
+		{&Options{LengthFirst: true}, "id0001", "id1000", -1},
+		{&Options{LengthFirst: true}, "id01", "id1", 1},
+		{&Options{LengthFirst: true}, "id01", "id02", -1},
+		{&Options{LengthFirst: true}, "id", "id00", -1},
+		{&Options{LengthFirst: true, Float: true}, "v1.999", "v10.1", -1},
+		{&Options{LengthFirst: true, DigitOrder: DigitsLexical}, "id0001", "id1000", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t-10", "t3", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t3", "t-10", 1},
+		{&Options{LengthFirst: true, Signed: true}, "t-0001", "t-999", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t-999", "t-1", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t-1", "t0", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t-01", "t-5", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t999", "t0001", -1},
+		{&Options{LengthFirst: true, Signed: true}, "t-0", "t00", -1}, // zero is not negative
+		{&Options{FixedWidth: 3}, "A7", "A07", -1},
+		{&Options{FixedWidth: 3}, "A9", "A07", -1},
+		{&Options{FixedWidth: 3}, "A99", "A007", -1},
+		{&Options{FixedWidth: 3}, "A007", "A010", -1},
+		{&Options{FixedWidth: 3}, "A999", "A1000", -1},
+		{&Options{FixedWidth: 3}, "A1000", "A01001", -1},
+		{&Options{FixedWidth: 3}, "A0001", "A1000", -1},
+		{&Options{FixedWidth: 3}, "A1000", "A0001", 1},
+		{&Options{FixedWidth: 3}, "A01000", "A1000", -1}, // equal keys
+		{&Options{FixedWidth: 3}, "A", "A000", -1},
+		{&Options{FixedWidth: 3, Float: true}, "v7.5", "v07.1", -1},
+		{&Options{FixedWidth: 3, DigitOrder: ShorterFirst}, "A1000", "A01000", -1},
+		{&Options{FixedWidth: 3, Signed: true}, "A-07", "A3", -1},
+		{&Options{FixedWidth: 3, Signed: true}, "A-007", "A-7", -1},
+		{&Options{FixedWidth: 3, Signed: true}, "A-1000", "A-007", -1},
+		{&Options{FixedWidth: 3, Signed: true}, "A7", "A007", -1},
+		{nil, "A9", "A07", 1},
+		{nil, "A007", "A7", -1}, // equal keys
+		{nil, "report1,000", "report999", -1},
+		{&Options{GroupSeparator: ','}, "report1,000", "report999", 1},
+		{&Options{GroupSeparator: ','}, "report1,000", "report1000", -1},
+		{&Options{Collator: collate.New(language.English, collate.IgnoreCase)}, "A2", "a10", -1},
+		{&Options{Collator: collate.New(language.English, collate.IgnoreCase)}, "A2", "a2", -1},
+		{&Options{Dates: true}, "b-2023-1-5", "b-2023-01-10", -1},
+		{&Options{Dates: true}, "b-2023-12-1", "b-2024-1-1", -1},
+		{&Options{Dates: true}, "b-2023-1-5", "b-2023-01-05", 1},
+		{&Options{Octal: true}, "f010", "f9", -1},
+		{&Options{Octal: true}, "f010", "f8", -1}, // equal keys
+		{&Options{Octal: true, DigitOrder: ShorterFirst}, "f010", "f8", 1},
+		{&Options{Octal: true, DigitOrder: LongerFirst}, "f010", "f8", -1},
+		{&Options{Attached: "%$€"}, "cost$5", "cost$100", -1},
+		{&Options{Attached: "%$€"}, "cost$100", "cost€3", -1},
+		{&Options{Attached: "%$€"}, "cost€3", "cost3", 1},
+		{&Options{TrimPrefix: "b/"}, "b/x2", "a/x10", 1},
+		{&Options{TrimPrefix: "b/"}, "b/x2", "x10", -1},
+		{&Options{TrimPrefix: "b/"}, "b/x2", "x2", -1},
+		{&Options{TrimPrefix: "b/"}, "x2", "b/x02", 1},
+		{&Options{Transform: strings.ToLower}, "B", "a", 1},
+		{&Options{Transform: strings.ToLower}, "A", "a", -1},
+		{&Options{Exponent: true}, "sample2e2", "sample1e3", -1},
+		{&Options{Exponent: true}, "sample1e2", "sample100", 1}, // equal keys
+		{&Options{Exponent: true}, "sample1e-2", "sample0", 1},
+		{&Options{Exponent: true}, "sample9e999", "sample1e1000", 1},
+		{&Options{ValuesDescending: true}, "buildA10", "buildA2", -1},
+		{&Options{ValuesDescending: true}, "buildA10", "buildB2", -1},
+		{&Options{ValuesDescending: true}, "buildB", "buildB2", -1},
+		{&Options{ValuesDescending: true}, "buildA01", "buildA1", -1},
+		{&Options{ValuesDescending: true, Signed: true}, "t-1", "t-5", -1},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal-$100", "bal-$20", -1},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal-$20", "bal$20", -1},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal$20", "bal€100", -1},
+		{&Options{ValuePrefix: "$€", Signed: true}, "bal$20", "bal20", -1}, // equal keys
+		{&Options{ValuePrefix: "$€"}, "bal-$20", "bal-$100", -1},
+		{nil, "bal-$20", "bal-$100", -1},
+		{&Options{RoundFloat64: true}, "id9007199254740993", "id9007199254740992", 1}, // equal keys
+		{&Options{RoundFloat64: true}, "id9007199254740994", "id9007199254740993", 1},
+		{&Options{RoundFloat64: true}, "x1" + strings.Repeat("0", 400), "x1" + strings.Repeat("0", 500), -1}, // equal keys
+		{&Options{RoundFloat64: true}, "x2" + strings.Repeat("0", 400), "x1" + strings.Repeat("0", 400), 1},  // equal keys
+		{nil, "x2" + strings.Repeat("0", 400), "x1" + strings.Repeat("0", 400), 1},
+		{nil, "id9007199254740993", "id9007199254740992", 1},
+		{&Options{PreRelease: "-"}, "2.3-beta", "2.3", -1},
+		{&Options{PreRelease: "-"}, "2.3-beta", "2.3-rc", -1},
+		{&Options{PreRelease: "-"}, "2.3-rc2", "2.3-rc10", -1},
+		{&Options{PreRelease: "-"}, "2.3-rc", "2.3.1", -1},
+		{&Options{PreRelease: "-"}, "2.3-rc", "2.3a", -1},
+		{&Options{PreRelease: "-"}, "2.3-rc", "2.2", 1},
+		{&Options{PreRelease: "-"}, "2024-01-05", "2024", 1},
+		{nil, "2.3-beta", "2.3", 1},
+		{&Options{CollapseSeparators: "_-"}, "a__2", "a_2", 1}, // equal keys
+		{&Options{CollapseSeparators: "_-"}, "a__2", "a_10", -1},
+		{&Options{CollapseSeparators: "_-"}, "a__10", "a_2", 1},
+		{&Options{CollapseSeparators: "_-"}, "a-_2", "a_10", -1},
+		{&Options{CollapseSeparators: "_-"}, "a-_2", "a_1", -1},
+		{&Options{Ignore: " #"}, "Item #2", "Item2", -1}, // equal keys
+		{&Options{Ignore: " #"}, "Item2", "Item #2", 1},  // equal keys
+		{&Options{Ignore: " #"}, "Item #2", "Item10", -1},
+		{&Options{Ignore: " #"}, "Item 1 2", "Item 12", -1},
+		{&Options{Ignore: " #"}, "Item-2", "Item #10", 1},
+		{&Options{Ignore: "-", FoldCase: true}, "A-b2", "ab10", -1},
+		{nil, "אב1", "בא1", -1},
+		{&Options{Bidi: true}, "אב1", "בא1", 1},
+		{&Options{Bidi: true}, "בא2", "בא10", -1},
+		{&Options{Bidi: true}, "file2", "file10", -1},
+		{&Options{Bidi: true}, "abc", "abd", -1},
+		{&Options{DigitFunc: devanagari}, "x३", "x10", -1},
+		{&Options{DigitFunc: devanagari}, "x१०", "x9", 1},
+		{&Options{FirstLine: true}, "item2\nnotes", "item10\nabc", -1},
+		{&Options{FirstLine: true}, "item2\nzzz", "item2\naaa", 1}, // equal keys
+		{&Options{FirstLine: true}, "item2", "item2\n", -1},
+		{&Options{SplitExtension: true}, "img2.png", "img10.jpeg", -1},
+		{&Options{SplitExtension: true}, "img10.jpeg", "img10.png", -1},
+		{&Options{SplitExtension: true}, "img10.png", "img10a.gif", -1},
+		{&Options{SplitExtension: true, FoldCase: true}, "a1.PNG", "a1.gif", 1},
+		{&Options{SplitExtension: true}, "img10.png", "img10-b.gif", -1},
+		{nil, "img10.png", "img10-b.gif", 1},
+		{nil, "item", "item1", -1},
+		{&Options{ValuesFirst: true}, "item", "item1", 1},
+		{&Options{ValuesFirst: true}, "item", "item0", 1},
+		{&Options{ValuesFirst: true}, "item", "items1", -1},
+		{&Options{ValuesFirst: true}, "item1", "items", -1},
+		{&Options{ValuesFirst: true}, "item1", "item2", -1},
+		{&Options{ValuesFirst: true}, "a1b", "a1b2", 1},
+		{&Options{ValuesFirst: true, ValuesDescending: true}, "item", "item9", 1},
+		{&Options{ValuesFirst: true, ValuesDescending: true}, "item1", "item9", 1},
+		{&Options{Durations: true}, "task90s", "task5m", -1},
+		{&Options{Durations: true}, "task5m", "task2h", -1},
+		{&Options{Durations: true}, "task49h", "task2d", 1},
+		{&Options{Durations: true}, "task90", "task90s", -1}, // equal keys
+		{desc, "a2", "a10", 1},
+		{desc, "a01", "a1", -1}, // tie is not reversed
+	}
+	for _, test := range tests {
+		if got := test.opts.Compare(test.a, test.b); got != test.want {
+			t.Errorf("Compare(%q, %q) %+v: got %v, want %v", test.a, test.b, test.opts, got, test.want)
+		}
+	}
+}
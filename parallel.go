@@ -0,0 +1,94 @@
+package stringsort
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// minParallel is the smallest input that StringsParallel sorts concurrently.
+// Below this size, the cost of coordinating goroutines exceeds the benefit.
+const minParallel = 4096
+
+// StringsParallel sorts ss in place, non-decreasing by mixed key, in the same
+// order as Strings. The keys are parsed and the input is sorted concurrently
+// in up to GOMAXPROCS pieces, which are then merged in parallel. If ss is
+// small, or only one processor is available, StringsParallel sorts ss
+// sequentially, as Strings does.
+//
+// StringsParallel uses additional memory proportional to len(ss).
+func StringsParallel(ss []string) {
+	n := runtime.GOMAXPROCS(0)
+	if n > len(ss)/(minParallel/4) {
+		n = len(ss) / (minParallel / 4) // keep pieces from becoming too small
+	}
+	if n < 2 || len(ss) < minParallel {
+		Strings(ss)
+		return
+	}
+
+	// Partition the input into n pieces of nearly equal size, and parse and
+	// sort each piece concurrently. The bounds of piece i are [b[i], b[i+1]).
+	b := make([]int, n+1)
+	for i := range b {
+		b[i] = i * len(ss) / n
+	}
+	src := byMixedKey{ss: ss, keys: make([]MixedKey, len(ss))}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			part := byMixedKey{ss: src.ss[lo:hi], keys: src.keys[lo:hi]}
+			for j, s := range part.ss {
+				part.keys[j] = ParseMixed(s)
+			}
+			sort.Sort(part)
+		}(b[i], b[i+1])
+	}
+	wg.Wait()
+
+	// Merge adjacent pairs of sorted pieces concurrently, alternating between
+	// the input and a buffer, until a single piece remains.
+	dst := byMixedKey{ss: make([]string, len(ss)), keys: make([]MixedKey, len(ss))}
+	for len(b) > 2 {
+		next := []int{0}
+		for i := 0; i+1 < len(b); i += 2 {
+			if i+2 == len(b) {
+				// An odd piece out is carried over unmerged.
+				lo, hi := b[i], b[i+1]
+				copy(dst.ss[lo:hi], src.ss[lo:hi])
+				copy(dst.keys[lo:hi], src.keys[lo:hi])
+				next = append(next, hi)
+				continue
+			}
+			wg.Add(1)
+			go func(lo, mid, hi int) {
+				defer wg.Done()
+				mergeKeys(dst, src, lo, mid, hi)
+			}(b[i], b[i+1], b[i+2])
+			next = append(next, b[i+2])
+		}
+		wg.Wait()
+		src, dst, b = dst, src, next
+	}
+	if &src.ss[0] != &ss[0] {
+		copy(ss, src.ss)
+	}
+}
+
+// mergeKeys merges the sorted ranges [lo, mid) and [mid, hi) of src into the
+// range [lo, hi) of dst. Ties are resolved in favor of the lower range, so the
+// result is the same as sorting the whole range of src.
+func mergeKeys(dst, src byMixedKey, lo, mid, hi int) {
+	i, j := lo, mid
+	for k := lo; k < hi; k++ {
+		if j == hi || (i < mid && src.opts.compareStrings(src.ss[j], src.ss[i], src.keys[j], src.keys[i]) >= 0) {
+			dst.ss[k], dst.keys[k] = src.ss[i], src.keys[i]
+			i++
+		} else {
+			dst.ss[k], dst.keys[k] = src.ss[j], src.keys[j]
+			j++
+		}
+	}
+}
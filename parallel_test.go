@@ -0,0 +1,43 @@
+package stringsort
+
+import (
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStringsParallel(t *testing.T) {
+	// Ensure the concurrent path is exercised even on a single processor.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(5))
+
+	for _, n := range []int{0, 1, 100, minParallel - 1, minParallel, 3*minParallel + 17, 50000} {
+		input := benchInput(n)
+		want := copyStrings(input)
+		Strings(want)
+
+		got := copyStrings(input)
+		StringsParallel(got)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("StringsParallel (n=%d): (-want, +got):\n%s", n, diff)
+		}
+	}
+}
+
+// Use the -cpu flag to compare the speedup for different numbers of cores.
+func BenchmarkStringsParallel(b *testing.B) {
+	input := benchInput(200000)
+	run := func(name string, sortFunc func([]string)) {
+		b.Run(name, func(b *testing.B) {
+			buf := make([]string, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(buf, input)
+				sortFunc(buf)
+			}
+		})
+	}
+	run("Strings", Strings)
+	run("StringsParallel", StringsParallel)
+	run("sort.Strings", sort.Strings)
+}
@@ -0,0 +1,42 @@
+package stringsort
+
+import "sync"
+
+// ParseMixedPooled parses s into a MixedKey, as ParseMixed, using storage
+// drawn from a shared pool. It returns the key along with a function that
+// returns its storage to the pool. This reduces allocation when many keys
+// are parsed and discarded, for example by concurrent requests in a server.
+//
+// The caller must call release exactly once, when it no longer needs the key,
+// and must not use the key (or spans retained from it) afterward:
+//
+//	key, release := stringsort.ParseMixedPooled(s)
+//	defer release()
+func ParseMixedPooled(s string) (_ MixedKey, release func()) {
+	kb, ok := keyPool.Get().(*keyBuf)
+	if !ok {
+		kb = newKeyBuf()
+	}
+	kb.key = parseMixed(s, nil, kb.key)
+	return kb.key, kb.release
+}
+
+// keyPool is a pool of *keyBuf values for ParseMixedPooled.
+var keyPool sync.Pool
+
+// A keyBuf is reusable storage for a key. Its release function is allocated
+// once, when the keyBuf is created, rather than by each use.
+type keyBuf struct {
+	key     MixedKey
+	release func()
+}
+
+func newKeyBuf() *keyBuf {
+	kb := new(keyBuf)
+	kb.release = func() {
+		clear(kb.key) // do not retain the strings of the key
+		kb.key = kb.key[:0]
+		keyPool.Put(kb)
+	}
+	return kb
+}
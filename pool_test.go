@@ -0,0 +1,53 @@
+package stringsort
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseMixedPooled(t *testing.T) {
+	input := benchInput(500)
+	input = append(input, "", "alpha25bravo-3", "101 dalmatians", "x")
+	opt := cmp.AllowUnexported(Span{})
+
+	// Parse concurrently, so that pooled storage is shared among goroutines.
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, s := range input {
+				key, release := ParseMixedPooled(s)
+				if diff := cmp.Diff(ParseMixed(s), key, opt, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("ParseMixedPooled(%q): (-want, +got):\n%s", s, diff)
+				}
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkParseMixedPooled(b *testing.B) {
+	input := benchInput(1000)
+	b.Run("ParseMixed", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				ParseMixed(input[i%len(input)])
+			}
+		})
+	})
+	b.Run("ParseMixedPooled", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				_, release := ParseMixedPooled(input[i%len(input)])
+				release()
+			}
+		})
+	})
+}
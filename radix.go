@@ -0,0 +1,123 @@
+package stringsort
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ByMixedKeyRadix returns a sorter that orders ss non-decreasing by mixed
+// key, in the same order as ByMixedKey. If the strings of ss share a uniform
+// structure, a common non-digit prefix followed by a run of digits whose
+// value fits in 64 bits, such as
+//
+//	frame7 frame10 frame003
+//
+// their order is computed by a radix sort on the values at the point of
+// construction, and the sorter compares precomputed ranks. Otherwise,
+// ByMixedKeyRadix returns the same sorter as ByMixedKey.
+func ByMixedKeyRadix(ss []string) sort.Interface {
+	vals, ok := uniformValues(ss)
+	if !ok {
+		return ByMixedKey(ss)
+	}
+
+	// Order the offsets of ss by value, then break ties lexicographically.
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+	}
+	radixSort(idx, vals)
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && vals[idx[j]] == vals[idx[i]] {
+			j++
+		}
+		if j-i > 1 {
+			tie := idx[i:j]
+			sort.Slice(tie, func(a, b int) bool { return ss[tie[a]] < ss[tie[b]] })
+		}
+		i = j
+	}
+
+	rank := make([]int, len(ss))
+	for r, i := range idx {
+		rank[i] = r
+	}
+	return byRank{ss: ss, rank: rank}
+}
+
+// byRank implements sort.Interface using precomputed ranks.
+type byRank struct {
+	ss   []string
+	rank []int
+}
+
+func (b byRank) Len() int           { return len(b.ss) }
+func (b byRank) Less(i, j int) bool { return b.rank[i] < b.rank[j] }
+
+func (b byRank) Swap(i, j int) {
+	b.ss[i], b.ss[j] = b.ss[j], b.ss[i]
+	b.rank[i], b.rank[j] = b.rank[j], b.rank[i]
+}
+
+// uniformValues reports whether each string of ss consists of the same
+// non-digit prefix followed by a nonempty run of ASCII digits whose value
+// fits in a uint64. If so, it returns the values of the strings.
+func uniformValues(ss []string) ([]uint64, bool) {
+	if len(ss) == 0 {
+		return nil, false
+	}
+	k := 0
+	for k < len(ss[0]) && !isDigit(ss[0][k]) {
+		k++
+	}
+	prefix := ss[0][:k]
+
+	vals := make([]uint64, len(ss))
+	for i, s := range ss {
+		digits, ok := strings.CutPrefix(s, prefix)
+		if !ok || digits == "" || !allDigits(digits, isDigit) {
+			return nil, false
+		} else if digits = trimZeros(digits); digits == "" {
+			continue // the value is zero
+		}
+		v, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil {
+			return nil, false // the value is too large
+		}
+		vals[i] = v
+	}
+	return vals, true
+}
+
+// radixSort stably sorts idx, whose elements are offsets into vals, in
+// non-decreasing order of the corresponding values, using a least-significant
+// digit radix sort with 8-bit digits.
+func radixSort(idx []int, vals []uint64) {
+	if len(idx) < 2 {
+		return
+	}
+	src, dst := idx, make([]int, len(idx))
+	for shift := 0; shift < 64; shift += 8 {
+		var count [257]int
+		for _, i := range src {
+			count[int(byte(vals[i]>>shift))+1]++
+		}
+		if count[int(byte(vals[src[0]]>>shift))+1] == len(src) {
+			continue // all values have the same digit in this position
+		}
+		for d := 1; d < len(count); d++ {
+			count[d] += count[d-1]
+		}
+		for _, i := range src {
+			d := byte(vals[i] >> shift)
+			dst[count[d]] = i
+			count[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &idx[0] {
+		copy(idx, src)
+	}
+}
@@ -0,0 +1,93 @@
+package stringsort
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUniformValues(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  []uint64 // nil if not uniform
+	}{
+		{nil, nil},
+		{[]string{"x"}, nil},
+		{[]string{"x1", "x"}, nil},
+		{[]string{"x1", "y2"}, nil},
+		{[]string{"x1", "x2y"}, nil},
+		{[]string{"x1", "x2-3"}, nil},
+		{[]string{"x1", "x99999999999999999999"}, nil},
+		{[]string{"x1", "x0", "x007"}, []uint64{1, 0, 7}},
+		{[]string{"5", "10", "0018446744073709551615"}, []uint64{5, 10, 1<<64 - 1}},
+		{[]string{"é1", "é22"}, []uint64{1, 22}},
+	}
+	for _, test := range tests {
+		got, ok := uniformValues(test.input)
+		if ok != (test.want != nil) {
+			t.Errorf("uniformValues(%q): got ok=%v, want %v", test.input, ok, test.want != nil)
+		} else if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("uniformValues(%q): (-want, +got):\n%s", test.input, diff)
+		}
+	}
+}
+
+func TestByMixedKeyRadix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	uniform := make([]string, 2000)
+	for i := range uniform {
+		switch i % 4 {
+		case 0:
+			uniform[i] = fmt.Sprintf("frame%d", r.Intn(500))
+		case 1:
+			uniform[i] = fmt.Sprintf("frame%03d", r.Intn(500))
+		case 2:
+			uniform[i] = fmt.Sprintf("frame%d", r.Uint64())
+		default:
+			uniform[i] = fmt.Sprintf("frame%d", r.Intn(1<<20))
+		}
+	}
+	tests := [][]string{
+		nil,
+		{"x1"},
+		{"x10", "x2", "x01", "x1", "x0", "x00", "x1"},
+		uniform,
+		append(copyStrings(uniform), "frame"),
+		benchInput(1000),
+	}
+	for _, input := range tests {
+		want := copyStrings(input)
+		sort.Sort(ByMixedKey(want))
+		got := copyStrings(input)
+		sort.Sort(ByMixedKeyRadix(got))
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ByMixedKeyRadix: (-want, +got):\n%s", diff)
+		}
+	}
+}
+
+func BenchmarkByMixedKeyRadix(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	uniform := make([]string, 10000)
+	for i := range uniform {
+		uniform[i] = fmt.Sprintf("frame%d", r.Intn(1000000))
+	}
+	mixed := benchInput(10000)
+
+	run := func(name string, input []string, sorter func([]string) sort.Interface) {
+		b.Run(name, func(b *testing.B) {
+			buf := make([]string, len(input))
+			for i := 0; i < b.N; i++ {
+				copy(buf, input)
+				sort.Sort(sorter(buf))
+			}
+		})
+	}
+	run("Uniform/ByMixedKey", uniform, ByMixedKey)
+	run("Uniform/ByMixedKeyRadix", uniform, ByMixedKeyRadix)
+	run("Mixed/ByMixedKey", mixed, ByMixedKey)
+	run("Mixed/ByMixedKeyRadix", mixed, ByMixedKeyRadix)
+}
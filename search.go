@@ -0,0 +1,36 @@
+package stringsort
+
+import (
+	"slices"
+	"sort"
+)
+
+// SearchMixed searches for target in ss, which must be sorted as by
+// ByMixedKey, and returns the index of the first element of ss that is not
+// less than target in mixed-key order. If there is no such element, it
+// returns len(ss). This is analogous to sort.SearchStrings.
+//
+// The result is meaningful only if ss is sorted by ByMixedKey (equivalently,
+// by Compare). Ties on key order are broken in the same way, so if target is
+// present in ss, the result is the index of its first occurrence.
+func SearchMixed(ss []string, target string) int {
+	key := ParseMixed(target)
+	return sort.Search(len(ss), func(i int) bool {
+		return (*Options)(nil).compareStrings(ss[i], target, ParseMixed(ss[i]), key) >= 0
+	})
+}
+
+// Insert inserts s into ss, which must be sorted as by ByMixedKey, at the
+// position reported by SearchMixed, and returns the updated slice, which
+// remains sorted. As with append, the underlying array of ss is reused if it
+// has room for another element, and the caller must use the result:
+//
+//	ss = stringsort.Insert(ss, s)
+//
+// Insert makes O(log n) comparisons to find the position, but takes O(n)
+// time to shift the elements after it, so it is suited to adding a few
+// strings to a sorted slice. To add many strings at once, it is more
+// efficient to append them and sort the whole slice.
+func Insert(ss []string, s string) []string {
+	return slices.Insert(ss, SearchMixed(ss, s), s)
+}
@@ -0,0 +1,65 @@
+package stringsort
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchMixed(t *testing.T) {
+	ss := []string{"a1", "a2", "a10", "b01", "b1", "b1", "b10"}
+	tests := []struct {
+		target string
+		want   int
+	}{
+		{"", 0},
+		{"a", 0},
+		{"a1", 0},
+		{"a01", 0},
+		{"a3", 2},
+		{"a10", 2},
+		{"a11", 3},
+		{"b001", 3},
+		{"b01", 3},
+		{"b1", 4},
+		{"b2", 6},
+		{"b10", 6},
+		{"b100", 7},
+		{"c", 7},
+	}
+	for _, test := range tests {
+		if got := SearchMixed(ss, test.target); got != test.want {
+			t.Errorf("SearchMixed(%q): got %d, want %d", test.target, got, test.want)
+		}
+	}
+	if got := SearchMixed(nil, "x"); got != 0 {
+		t.Errorf("SearchMixed(nil, x): got %d, want 0", got)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	input := append(benchInput(50), "", "a1", "a01", "a1", "b10", "b2", "B2")
+
+	// Inserting the strings one at a time in any order gives the same result
+	// as sorting them.
+	want := copyStrings(input)
+	Strings(want)
+	for i := 0; i < 10; i++ {
+		var got []string
+		for _, j := range rand.Perm(len(input)) {
+			got = Insert(got, input[j])
+			if err := CheckSorted(got); err != nil {
+				t.Fatalf("Insert %q: %v", input[j], err)
+			}
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Insert: (-want, +got):\n%s", diff)
+		}
+	}
+
+	got := Insert([]string{"a1", "a2", "a10"}, "a3")
+	if diff := cmp.Diff([]string{"a1", "a2", "a3", "a10"}, got); diff != "" {
+		t.Errorf("Insert a3: (-want, +got):\n%s", diff)
+	}
+}
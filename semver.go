@@ -0,0 +1,166 @@
+package stringsort
+
+import (
+	"sort"
+	"strings"
+)
+
+// BySemver returns a sorter that orders ss non-decreasing by semantic version
+// precedence, as defined by https://semver.org. Strings that are not valid
+// semantic versions are ordered by mixed key, after all the valid versions.
+// An optional "v" prefix is permitted, so "v1.2.0" and "1.2.0" are both valid
+// and have equal precedence.
+//
+// For example, the following are in order:
+//
+//	v1.2.0-rc1 v1.2.0 v1.10.0 latest v1.2 v1.10
+//
+// Build metadata (following a "+") does not affect precedence. Strings with
+// equal precedence, such as "v1.0.0+a" and "1.0.0+b", are ordered by their
+// lexicographic order.
+func BySemver(ss []string) sort.Interface {
+	kp := bySemver{
+		byMixedKey: byMixedKey{
+			ss:   ss,
+			keys: make([]MixedKey, len(ss)),
+		},
+		vs: make([]*semver, len(ss)),
+	}
+	for i, s := range ss {
+		if v, ok := parseSemver(s); ok {
+			kp.vs[i] = &v
+		} else {
+			kp.keys[i] = ParseMixed(s)
+		}
+	}
+	return kp
+}
+
+// bySemver implements sort.Interface for semantic versions, falling back to
+// mixed keys for strings that are not valid versions.
+type bySemver struct {
+	byMixedKey
+	vs []*semver // parsed versions, or nil if not valid
+}
+
+func (b bySemver) Less(i, j int) bool {
+	vi, vj := b.vs[i], b.vs[j]
+	switch {
+	case vi == nil && vj == nil:
+		return b.byMixedKey.Less(i, j)
+	case vi == nil || vj == nil:
+		return vi != nil // versions sort before non-versions
+	}
+	if c := compareSemver(*vi, *vj); c != 0 {
+		return c < 0
+	}
+	return b.ss[i] < b.ss[j]
+}
+
+func (b bySemver) Swap(i, j int) {
+	b.byMixedKey.Swap(i, j)
+	b.vs[i], b.vs[j] = b.vs[j], b.vs[i]
+}
+
+// A semver is a parsed semantic version. Build metadata is not retained.
+type semver struct {
+	major, minor, patch string   // numeric, without leading zeros
+	pre                 []string // pre-release identifiers, if any
+}
+
+// parseSemver parses s as a semantic version with an optional "v" prefix,
+// and reports whether it is valid.
+func parseSemver(s string) (semver, bool) {
+	var v semver
+	s = strings.TrimPrefix(s, "v")
+
+	// Discard build metadata, after checking that it is well-formed.
+	s, build, hasBuild := strings.Cut(s, "+")
+	if hasBuild && !validIdents(build, false) {
+		return v, false
+	}
+	s, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		if !validIdents(pre, true) {
+			return v, false
+		}
+		v.pre = strings.Split(pre, ".")
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for _, p := range parts {
+		if !isNumericIdent(p) {
+			return v, false
+		}
+	}
+	v.major, v.minor, v.patch = parts[0], parts[1], parts[2]
+	return v, true
+}
+
+// validIdents reports whether s is a non-empty dot-separated sequence of
+// non-empty identifiers comprising ASCII letters, digits, and hyphens. If
+// numeric is true, identifiers consisting only of digits must not have
+// leading zeros.
+func validIdents(s string, numeric bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			if c := id[i]; !isDigit(c) && c != '-' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') {
+				return false
+			}
+		}
+		if numeric && allDigits(id, isDigit) && !isNumericIdent(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericIdent reports whether s is a non-empty string of digits without
+// leading zeros (other than "0" itself).
+func isNumericIdent(s string) bool {
+	return allDigits(s, isDigit) && (s == "0" || s[0] != '0')
+}
+
+// compareSemver compares a and b by semantic version precedence.
+func compareSemver(a, b semver) int {
+	if c := compareNum(a.major, b.major); c != 0 {
+		return c
+	} else if c := compareNum(a.minor, b.minor); c != 0 {
+		return c
+	} else if c := compareNum(a.patch, b.patch); c != 0 {
+		return c
+	}
+
+	// A version without pre-release identifiers has higher precedence.
+	if len(a.pre) == 0 || len(b.pre) == 0 {
+		return compareInt(len(b.pre), len(a.pre))
+	}
+	for i := 0; i < len(a.pre) && i < len(b.pre); i++ {
+		if c := comparePreIdent(a.pre[i], b.pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a.pre), len(b.pre))
+}
+
+// comparePreIdent compares pre-release identifiers a and b. Numeric
+// identifiers compare by value, and have lower precedence than alphanumeric
+// identifiers, which compare lexicographically.
+func comparePreIdent(a, b string) int {
+	an, bn := allDigits(a, isDigit), allDigits(b, isDigit)
+	switch {
+	case an && bn:
+		return compareNum(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
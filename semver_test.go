@@ -0,0 +1,85 @@
+package stringsort
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		input string
+		ok    bool
+	}{
+		{"", false},
+		{"1", false},
+		{"1.2", false},
+		{"v1.2", false},
+		{"1.2.3", true},
+		{"v1.2.3", true},
+		{"vv1.2.3", false},
+		{"1.2.3.4", false},
+		{"01.2.3", false},
+		{"1.2.x", false},
+		{"1.2.3-", false},
+		{"1.2.3-rc1", true},
+		{"1.2.3-rc.1", true},
+		{"1.2.3-rc..1", false},
+		{"1.2.3-01", false},
+		{"1.2.3-0a", true},
+		{"1.2.3-x-y", true},
+		{"1.2.3+", false},
+		{"1.2.3+001", true},
+		{"1.2.3-rc1+build.5", true},
+		{"1.2.3+build_5", false},
+		{"0.0.0", true},
+	}
+	for _, test := range tests {
+		if _, ok := parseSemver(test.input); ok != test.ok {
+			t.Errorf("parseSemver(%q): got %v, want %v", test.input, ok, test.ok)
+		}
+	}
+}
+
+func TestBySemver(t *testing.T) {
+	// The input slice must have the expected order.
+	input := []string{
+		// Examples from the semver specification.
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+
+		// Equal precedence, broken lexicographically.
+		"1.0.0+build.2",
+		"v1.0.0",
+
+		"v1.2.0-rc1",
+		"v1.2.0",
+		"v1.10.0",
+		"v99999999999999999999.0.0",
+
+		// Non-versions, by mixed key.
+		"latest",
+		"v1.2",
+		"v1.10",
+	}
+	for i := 0; i < 50; i++ {
+		cp := copyStrings(input)
+		rand.Shuffle(len(cp), func(i, j int) {
+			cp[i], cp[j] = cp[j], cp[i]
+		})
+
+		got := copyStrings(cp)
+		sort.Sort(BySemver(got))
+		if diff := cmp.Diff(input, got); diff != "" {
+			t.Errorf("BySemver(%+q): (-want, +got):\n%s", cp, diff)
+		}
+	}
+}
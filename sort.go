@@ -0,0 +1,316 @@
+package stringsort
+
+import (
+	"container/heap"
+	"fmt"
+	"iter"
+	"math/big"
+	"sort"
+)
+
+// Strings sorts ss in place, non-decreasing by mixed key. It is shorthand for
+// sort.Sort(ByMixedKey(ss)).
+func Strings(ss []string) { sort.Sort(ByMixedKey(ss)) }
+
+// LessFunc returns a function that reports whether ss[i] < ss[j] by mixed key,
+// with ties broken as for ByMixedKey, for use with sort.Slice:
+//
+//	sort.Slice(ss, stringsort.LessFunc(ss))
+//
+// The keys of the strings of ss are parsed once, when LessFunc is called, and
+// are cached by value rather than by position, so the function remains
+// correct as sort.Slice permutes ss. It is only valid for the exact slice
+// passed to LessFunc, and only while its elements are not modified other than
+// by permuting them.
+func LessFunc(ss []string) func(i, j int) bool {
+	keys := make(map[string]MixedKey, len(ss))
+	for _, s := range ss {
+		if _, ok := keys[s]; !ok {
+			keys[s] = ParseMixed(s)
+		}
+	}
+	return func(i, j int) bool {
+		a, b := ss[i], ss[j]
+		return (*Options)(nil).compareStrings(a, b, keys[a], keys[b]) < 0
+	}
+}
+
+// StringsAreSorted reports whether ss is sorted non-decreasing by mixed key,
+// in the order produced by Strings.
+func StringsAreSorted(ss []string) bool { return CheckSorted(ss) == nil }
+
+// CheckSorted reports whether ss is sorted non-decreasing by mixed key, in
+// the order produced by Strings. If so, it returns nil; otherwise it returns
+// an error describing the first adjacent pair of strings that are out of
+// order, for example:
+//
+//	strings at 3 and 4 are out of order: "a10" > "a2"
+func CheckSorted(ss []string) error {
+	var prev MixedKey
+	for i, s := range ss {
+		key := ParseMixed(s)
+		if i > 0 && (*Options)(nil).compareStrings(ss[i-1], s, prev, key) > 0 {
+			return fmt.Errorf("strings at %d and %d are out of order: %q > %q", i-1, i, ss[i-1], s)
+		}
+		prev = key
+	}
+	return nil
+}
+
+// SortedKeys returns the keys of m ordered by mixed key, as by ByMixedKey.
+// If m is empty, SortedKeys returns an empty, non-nil slice.
+func SortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Sort(ByMixedKey(keys))
+	return keys
+}
+
+// GroupByKey sorts a copy of ss by mixed key, and partitions the result into
+// groups of strings whose mixed keys are equal, such as "echo001", "echo01",
+// and "echo1". The groups are in mixed-key order, and the strings in each
+// group are in lexicographic order. Strings whose keys differ in any way,
+// including only in the value of a digit run, are in different groups.
+//
+// GroupByKey does not modify ss. If ss is empty, the result is empty.
+func GroupByKey(ss []string) [][]string {
+	return groupBy(ss, func(a, b MixedKey) bool { return compareMixed(a, b) == 0 })
+}
+
+// GroupByKeyWithin sorts a copy of ss by mixed key, and partitions the result
+// into groups of strings whose mixed keys are equal within a tolerance of
+// delta, so that for example with delta 1, "v100" and "v101" are grouped. Two
+// keys are equal within delta if they have the same number of spans, and the
+// corresponding spans have equal non-digit runs and either both have no value
+// or have values that differ by at most delta. The tolerance applies to each
+// span separately, so with delta 1, "v100x5" and "v101x6" are grouped, but
+// "v100x5" and "v101x7" are not.
+//
+// Since this relation is not transitive, groups are formed from consecutive
+// strings in sorted order: Each group begins with the least string not in a
+// previous group, and contains each following string whose key is equal to
+// the key of that first string within delta. So with delta 1, "v100",
+// "v101", and "v102" form the groups {"v100", "v101"} and {"v102"}.
+//
+// With delta 0, GroupByKeyWithin is equivalent to GroupByKey. It does not
+// modify ss. If ss is empty, the result is empty.
+func GroupByKeyWithin(ss []string, delta uint64) [][]string {
+	d := new(big.Int).SetUint64(delta)
+	return groupBy(ss, func(a, b MixedKey) bool { return keysWithin(a, b, d) })
+}
+
+// groupBy sorts a copy of ss by mixed key, and partitions the result into
+// groups of consecutive strings whose keys are equivalent to the key of the
+// first string in the group according to eq.
+func groupBy(ss []string, eq func(a, b MixedKey) bool) [][]string {
+	kp := (*Options)(nil).byMixedKey(copyOf(ss))
+	sort.Sort(kp)
+
+	var groups [][]string
+	for i := 0; i < len(kp.ss); {
+		j := i + 1
+		for j < len(kp.ss) && eq(kp.keys[i], kp.keys[j]) {
+			j++
+		}
+		groups = append(groups, kp.ss[i:j:j])
+		i = j
+	}
+	return groups
+}
+
+// keysWithin reports whether keys a and b are equal within delta, as
+// described for GroupByKeyWithin.
+func keysWithin(a, b MixedKey, delta *big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var x, y big.Int
+	for i, sa := range a {
+		sb := b[i]
+		if sa.run != sb.run || sa.hasNum != sb.hasNum {
+			return false
+		} else if !sa.hasNum || sa.num == sb.num {
+			continue
+		}
+		x.SetString("0"+sa.num, 10)
+		y.SetString("0"+sb.num, 10)
+		if x.Sub(&x, &y).Abs(&x).Cmp(delta) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ArgSort returns the indices of the strings of ss in the order produced by
+// Strings, so that ss[idx[0]], ss[idx[1]], ... are sorted by mixed key. It
+// does not modify ss. Ties on key order are broken using the lexicographic
+// order of the strings, and identical strings are ordered by their indices,
+// so the result is deterministic. The result can be used to apply the same
+// permutation to other slices parallel to ss.
+func ArgSort(ss []string) []int {
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Stable(NewKeyer(ss).ByIndex(idx))
+	return idx
+}
+
+// SmallestN returns the n smallest strings of ss by mixed key, in the order
+// produced by Strings. If n > len(ss), all the strings of ss are returned. If
+// n <= 0 or ss is empty, the result is empty and non-nil.
+//
+// SmallestN uses a bounded heap, so it takes O(len(ss) * log n) time rather
+// than sorting all of ss. It does not modify ss.
+func SmallestN(ss []string, n int) []string {
+	n = min(n, len(ss))
+	if n <= 0 {
+		return []string{}
+	}
+	h := &maxHeap{byMixedKey{
+		ss:   make([]string, 0, n),
+		keys: make([]MixedKey, 0, n),
+	}}
+	var buf MixedKey
+	for _, s := range ss {
+		key := ParseMixedInto(s, buf)
+		if h.Len() < n {
+			heap.Push(h, keyed{s, key})
+			buf = nil
+		} else if h.opts.compareStrings(s, h.ss[0], key, h.keys[0]) < 0 {
+			// Replace the largest element, and reuse its key storage.
+			buf = h.keys[0]
+			h.ss[0], h.keys[0] = s, key
+			heap.Fix(h, 0)
+		} else {
+			buf = key
+		}
+	}
+	sort.Sort(h.byMixedKey)
+	return h.ss
+}
+
+// Ordered returns a sequence of the strings of ss in the order produced by
+// Strings, without sorting ss. Each iteration of the sequence parses the keys
+// of ss and arranges them in a heap, in O(len(ss)) time, and then finds each
+// successive string in O(log len(ss)) time, so a caller that stops early
+// need not pay for sorting the remainder. For example:
+//
+//	for s := range stringsort.Ordered(ss) {
+//		if done(s) {
+//			break
+//		}
+//	}
+//
+// The caller must not modify ss while the sequence is in use.
+func Ordered(ss []string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		h := &minHeap{(*Options)(nil).byMixedKey(copyOf(ss))}
+		heap.Init(h)
+		for h.Len() != 0 {
+			if !yield(heap.Pop(h).(keyed).s) {
+				return
+			}
+		}
+	}
+}
+
+// Merge merges inputs, each of which must be sorted by mixed key in the order
+// produced by Strings, and returns a new slice containing all their strings
+// in the same order. The inputs are not modified. If all the inputs are empty,
+// the result is empty and non-nil.
+//
+// Merge takes O(n * log k) time for n strings in k inputs, and parses each
+// string once. If an input is not sorted, the order of the result is
+// unspecified.
+func Merge(inputs ...[]string) []string {
+	var total int
+	for _, in := range inputs {
+		total += len(in)
+	}
+	out := make([]string, 0, total)
+
+	h := make(mergeHeap, 0, len(inputs))
+	for _, in := range inputs {
+		if len(in) != 0 {
+			h = append(h, &mergeInput{ss: in, key: ParseMixed(in[0])})
+		}
+	}
+	heap.Init(&h)
+	for len(h) != 0 {
+		m := h[0]
+		out = append(out, m.ss[0])
+		if m.ss = m.ss[1:]; len(m.ss) == 0 {
+			heap.Pop(&h)
+		} else {
+			m.key = ParseMixedInto(m.ss[0], m.key)
+			heap.Fix(&h, 0)
+		}
+	}
+	return out
+}
+
+// mergeInput is the unmerged remainder of an input to Merge, with the mixed
+// key of its first string.
+type mergeInput struct {
+	ss  []string
+	key MixedKey
+}
+
+// mergeHeap implements heap.Interface for inputs to Merge, ordered by the
+// first string of each input.
+type mergeHeap []*mergeInput
+
+func (h mergeHeap) Len() int { return len(h) }
+
+func (h mergeHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	return (*Options)(nil).compareStrings(a.ss[0], b.ss[0], a.key, b.key) < 0
+}
+
+func (h mergeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *mergeHeap) Push(x any) { *h = append(*h, x.(*mergeInput)) }
+
+func (h *mergeHeap) Pop() any {
+	n := len(*h) - 1
+	m := (*h)[n]
+	*h = (*h)[:n]
+	return m
+}
+
+// keyed is a string paired with its mixed key.
+type keyed struct {
+	s   string
+	key MixedKey
+}
+
+// minHeap implements heap.Interface for strings, with the smallest string by
+// mixed key at the root.
+type minHeap struct{ byMixedKey }
+
+func (h *minHeap) Push(x any) { (*maxHeap)(h).Push(x) }
+func (h *minHeap) Pop() any   { return (*maxHeap)(h).Pop() }
+
+// maxHeap implements heap.Interface for strings, with the largest string by
+// mixed key at the root.
+type maxHeap struct{ byMixedKey }
+
+func (h *maxHeap) Less(i, j int) bool { return h.byMixedKey.Less(j, i) }
+
+func (h *maxHeap) Push(x any) {
+	e := x.(keyed)
+	h.ss = append(h.ss, e.s)
+	h.keys = append(h.keys, e.key)
+}
+
+func (h *maxHeap) Pop() any {
+	n := len(h.ss) - 1
+	e := keyed{h.ss[n], h.keys[n]}
+	h.ss, h.keys = h.ss[:n], h.keys[:n]
+	return e
+}
+
+func copyOf(ss []string) []string { return append([]string(nil), ss...) }
@@ -0,0 +1,277 @@
+package stringsort
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStrings(t *testing.T) {
+	input := []string{"b10", "a2", "b1", "a10", "b01", "a1", "b1"}
+	if StringsAreSorted(input) {
+		t.Errorf("StringsAreSorted(%q): got true, want false", input)
+	}
+	Strings(input)
+	want := []string{"a1", "a2", "a10", "b01", "b1", "b1", "b10"}
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("Strings: (-want, +got):\n%s", diff)
+	}
+	if !StringsAreSorted(input) {
+		t.Errorf("StringsAreSorted(%q): got false, want true", input)
+	}
+}
+
+func TestLessFunc(t *testing.T) {
+	input := benchInput(1000)
+	input = append(input, "b10", "a2", "b1", "a10", "b01", "a1", "b1", "")
+	want := copyStrings(input)
+	Strings(want)
+
+	sort.Slice(input, LessFunc(input))
+	if diff := cmp.Diff(want, input); diff != "" {
+		t.Errorf("sort.Slice with LessFunc: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestStringsAreSorted(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  bool
+	}{
+		{nil, true},
+		{[]string{"x"}, true},
+		{[]string{"x2", "x10"}, true},
+		{[]string{"x10", "x2"}, false},
+		{[]string{"x01", "x1"}, true},
+		{[]string{"x1", "x01"}, false},
+		{[]string{"a", "a", "b"}, true},
+		{[]string{"a1", "a2", "a3", "a20", "a10"}, false},
+	}
+	for _, test := range tests {
+		if got := StringsAreSorted(test.input); got != test.want {
+			t.Errorf("StringsAreSorted(%q): got %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestCheckSorted(t *testing.T) {
+	tests := []struct {
+		input []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"x"}, ""},
+		{[]string{"x2", "x10"}, ""},
+		{[]string{"x10", "x2"}, `strings at 0 and 1 are out of order: "x10" > "x2"`},
+		{[]string{"x1", "x01"}, `strings at 0 and 1 are out of order: "x1" > "x01"`},
+		{[]string{"a", "a", "b"}, ""},
+		{[]string{"a1", "a2", "a3", "a20", "a10"}, `strings at 3 and 4 are out of order: "a20" > "a10"`},
+		{[]string{"b", "a", "c", "a"}, `strings at 0 and 1 are out of order: "b" > "a"`},
+	}
+	for _, test := range tests {
+		err := CheckSorted(test.input)
+		if test.want == "" {
+			if err != nil {
+				t.Errorf("CheckSorted(%q): unexpected error: %v", test.input, err)
+			}
+		} else if err == nil {
+			t.Errorf("CheckSorted(%q): got nil, want error %q", test.input, test.want)
+		} else if got := err.Error(); got != test.want {
+			t.Errorf("CheckSorted(%q): got error %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	got := SortedKeys(map[string]int{})
+	if got == nil || len(got) != 0 {
+		t.Errorf("SortedKeys(empty): got %#v, want empty", got)
+	}
+
+	got = SortedKeys(map[string]bool{
+		"row10": true, "row2": false, "row1": true, "header": true, "row01": false,
+	})
+	want := []string{"header", "row01", "row1", "row2", "row10"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SortedKeys: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestGroupByKey(t *testing.T) {
+	if got := GroupByKey(nil); len(got) != 0 {
+		t.Errorf("GroupByKey(nil): got %q, want empty", got)
+	}
+
+	input := []string{"echo1", "bravo", "echo01", "echo2", "echo001", "bravo", "echo10"}
+	got := GroupByKey(input)
+	want := [][]string{
+		{"bravo", "bravo"},
+		{"echo001", "echo01", "echo1"},
+		{"echo2"},
+		{"echo10"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GroupByKey(%q): (-want, +got):\n%s", input, diff)
+	}
+	if diff := cmp.Diff([]string{"echo1", "bravo", "echo01", "echo2", "echo001", "bravo", "echo10"}, input); diff != "" {
+		t.Errorf("GroupByKey modified its input: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestGroupByKeyWithin(t *testing.T) {
+	if got := GroupByKeyWithin(nil, 1); len(got) != 0 {
+		t.Errorf("GroupByKeyWithin(nil): got %q, want empty", got)
+	}
+
+	tests := []struct {
+		input []string
+		delta uint64
+		want  [][]string
+	}{
+		{[]string{"v101", "v100"}, 0, [][]string{{"v100"}, {"v101"}}},
+		{[]string{"v101", "v100"}, 1, [][]string{{"v100", "v101"}}},
+		{[]string{"v102", "v101", "v100"}, 1, [][]string{{"v100", "v101"}, {"v102"}}},
+		{[]string{"v102", "v101", "v100"}, 2, [][]string{{"v100", "v101", "v102"}}},
+		{[]string{"v100", "w101", "v"}, 5, [][]string{{"v"}, {"v100"}, {"w101"}}},
+		{[]string{"v100x5", "v101x6"}, 1, [][]string{{"v100x5", "v101x6"}}},
+		{[]string{"v100x5", "v101x7"}, 1, [][]string{{"v100x5"}, {"v101x7"}}},
+		{[]string{"v1", "v1x"}, 1, [][]string{{"v1"}, {"v1x"}}},
+		{[]string{"v0", "v", "v1"}, 1, [][]string{{"v"}, {"v0", "v1"}}},
+		{
+			[]string{"n99999999999999999999", "n100000000000000000000"}, 1,
+			[][]string{{"n99999999999999999999", "n100000000000000000000"}},
+		},
+	}
+	for _, test := range tests {
+		got := GroupByKeyWithin(test.input, test.delta)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("GroupByKeyWithin(%q, %d): (-want, +got):\n%s", test.input, test.delta, diff)
+		}
+	}
+
+	// With delta 0, the result is the same as GroupByKey.
+	input := benchInput(500)
+	if diff := cmp.Diff(GroupByKey(input), GroupByKeyWithin(input, 0)); diff != "" {
+		t.Errorf("GroupByKeyWithin(0): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestArgSort(t *testing.T) {
+	if got := ArgSort(nil); len(got) != 0 {
+		t.Errorf("ArgSort(nil): got %v, want empty", got)
+	}
+
+	input := []string{"file10", "file2", "b", "file01", "file1", "b", "a"}
+	orig := copyStrings(input)
+	got := ArgSort(input)
+	want := []int{6, 2, 5, 3, 4, 1, 0}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ArgSort(%q): (-want, +got):\n%s", input, diff)
+	}
+	if diff := cmp.Diff(orig, input); diff != "" {
+		t.Errorf("ArgSort modified its input: (-want, +got):\n%s", diff)
+	}
+
+	// Applying the permutation gives the same order as Strings.
+	sorted := copyStrings(input)
+	Strings(sorted)
+	for i, j := range got {
+		if input[j] != sorted[i] {
+			t.Errorf("Element %d: got %q, want %q", i, input[j], sorted[i])
+		}
+	}
+}
+
+func TestSmallestN(t *testing.T) {
+	input := []string{"b10", "a2", "b1", "a10", "b01", "a1", "b1", "c"}
+	sorted := copyStrings(input)
+	Strings(sorted)
+
+	for n := -1; n <= len(input)+1; n++ {
+		want := sorted[:max(0, min(n, len(sorted)))]
+		got := SmallestN(input, n)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("SmallestN(%d): (-want, +got):\n%s", n, diff)
+		}
+	}
+	if got := SmallestN(nil, 3); got == nil || len(got) != 0 {
+		t.Errorf("SmallestN(nil, 3): got %#v, want empty", got)
+	}
+
+	large := benchInput(5000)
+	want := copyStrings(large)
+	Strings(want)
+	if diff := cmp.Diff(want[:20], SmallestN(large, 20)); diff != "" {
+		t.Errorf("SmallestN(large, 20): (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	if got := Merge(); got == nil || len(got) != 0 {
+		t.Errorf("Merge(): got %#v, want empty", got)
+	}
+	if got := Merge(nil, []string{}); got == nil || len(got) != 0 {
+		t.Errorf("Merge(nil, empty): got %#v, want empty", got)
+	}
+
+	tests := [][][]string{
+		{{"a1", "a2", "a10"}},
+		{{"a1", "a10"}, nil, {"a2"}},
+		{{"x01", "x1", "x10"}, {"x1", "x2", "y"}, {"", "x001"}},
+		{{"b1", "b2"}, {"a1", "a2"}, {"c"}, {"a10", "b10", "c1"}},
+	}
+	for _, inputs := range tests {
+		var want []string
+		for _, in := range inputs {
+			want = append(want, in...)
+		}
+		Strings(want)
+		if diff := cmp.Diff(want, Merge(inputs...)); diff != "" {
+			t.Errorf("Merge(%q): (-want, +got):\n%s", inputs, diff)
+		}
+	}
+
+	// The inputs are not modified.
+	a, b := []string{"n1", "n3"}, []string{"n2"}
+	Merge(a, b)
+	if diff := cmp.Diff([]string{"n1", "n3"}, a); diff != "" {
+		t.Errorf("Merge modified its input: (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOrdered(t *testing.T) {
+	input := []string{"b10", "a2", "b1", "a10", "b01", "a1", "b1", "c"}
+	orig := copyStrings(input)
+	want := copyStrings(input)
+	Strings(want)
+
+	var got []string
+	for s := range Ordered(input) {
+		got = append(got, s)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Ordered: (-want, +got):\n%s", diff)
+	}
+
+	// Stopping early yields a prefix of the sorted order.
+	got = nil
+	for s := range Ordered(input) {
+		if len(got) == 3 {
+			break
+		}
+		got = append(got, s)
+	}
+	if diff := cmp.Diff(want[:3], got); diff != "" {
+		t.Errorf("Ordered (prefix): (-want, +got):\n%s", diff)
+	}
+
+	// The input is not modified.
+	if diff := cmp.Diff(orig, input); diff != "" {
+		t.Errorf("Ordered modified its input: (-want, +got):\n%s", diff)
+	}
+
+	for range Ordered(nil) {
+		t.Error("Ordered(nil) yielded a value")
+	}
+}
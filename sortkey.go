@@ -0,0 +1,49 @@
+package stringsort
+
+import "encoding/binary"
+
+// EncodeKey returns a byte string encoding the mixed key of s, such that
+// bytes.Compare(EncodeKey(a), EncodeKey(b)) == Compare(a, b) for all strings
+// a and b. This allows strings to be sorted by comparing their encoded keys
+// alone, for example with slices.SortFunc and bytes.Compare, or to be stored
+// in an ordered database index.
+//
+// The encoding is the concatenation of the encodings of the spans of the key,
+// followed by the bytes 0x00 0x00 and then the bytes of s itself, so that
+// strings with equal keys are ordered lexicographically. Each span is encoded
+// as its non-digit run, with each 0x00 byte escaped as 0x00 0xFF, followed by
+// 0x00 0x01 if the span has no value, or by 0x00 0x02 and the value if it
+// does. A value is encoded as the number of its digits, without leading
+// zeros, as a single byte if the number is less than 255 or otherwise as the
+// byte 0xFF followed by the number as an 8-byte big-endian integer, and then
+// the digits themselves.
+//
+// The encoding is defined only for the default order of ParseMixed and
+// Compare, and does not reflect any Options. It is at least as long as s.
+func EncodeKey(s string) []byte {
+	buf := make([]byte, 0, 2*len(s)+2)
+	for i := 0; i < len(s); {
+		run, digits, next := nextSpan(s, i)
+		for j := 0; j < len(run); j++ {
+			if run[j] == 0 {
+				buf = append(buf, 0, 0xff)
+			} else {
+				buf = append(buf, run[j])
+			}
+		}
+		if digits == "" {
+			buf = append(buf, 0, 1) // no value; this is the last span
+		} else {
+			num := trimZeros(digits)
+			buf = append(buf, 0, 2)
+			if len(num) < 0xff {
+				buf = append(buf, byte(len(num)))
+			} else {
+				buf = binary.BigEndian.AppendUint64(append(buf, 0xff), uint64(len(num)))
+			}
+			buf = append(buf, num...)
+		}
+		i = next
+	}
+	return append(append(buf, 0, 0), s...)
+}
@@ -0,0 +1,82 @@
+package stringsort
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncodeKey(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "\x00\x00"},
+		{"a", "a\x00\x01\x00\x00a"},
+		{"a01", "a\x00\x02\x011\x00\x00a01"},
+		{"0", "\x00\x02\x00\x00\x000"},
+		{"x2y", "x\x00\x02\x012y\x00\x01\x00\x00x2y"},
+		{"\x00", "\x00\xff\x00\x01\x00\x00\x00"},
+	}
+	for _, test := range tests {
+		if got := string(EncodeKey(test.input)); got != test.want {
+			t.Errorf("EncodeKey(%q): got %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestEncodeKeyOrder(t *testing.T) {
+	long := strings.Repeat("9", 300)
+	input := append(benchInput(100),
+		"", "a", "a\x00", "a\x00b", "a\x001", "a1", "a01", "a1\x00", "a1b", "a10", "1a", "0", "00",
+		"x"+long, "x1"+long, "x"+strings.Repeat("0", 300)+"5", "x5", "x"+long+"y", "é2", "é10",
+	)
+	for _, a := range input {
+		for _, b := range input {
+			if got, want := bytes.Compare(EncodeKey(a), EncodeKey(b)), Compare(a, b); got != want {
+				t.Errorf("Compare encoded %q, %q: got %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Sorting by the encoded keys gives the same order as Strings.
+	want := copyStrings(input)
+	Strings(want)
+	keys := make([][]byte, len(input))
+	for i, s := range input {
+		keys[i] = EncodeKey(s)
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	got := make([]string, len(keys))
+	for i, key := range keys {
+		got[i] = input[slices.IndexFunc(input, func(s string) bool {
+			return bytes.Equal(EncodeKey(s), key)
+		})]
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Sort by encoded keys: (-want, +got):\n%s", diff)
+	}
+}
+
+func BenchmarkEncodeKey(b *testing.B) {
+	input := benchInput(10000)
+	b.Run("ByMixedKey", func(b *testing.B) {
+		buf := make([]string, len(input))
+		for i := 0; i < b.N; i++ {
+			copy(buf, input)
+			Strings(buf)
+		}
+	})
+	b.Run("EncodeKey", func(b *testing.B) {
+		keys := make([][]byte, len(input))
+		for i := 0; i < b.N; i++ {
+			for j, s := range input {
+				keys[j] = EncodeKey(s)
+			}
+			slices.SortFunc(keys, bytes.Compare)
+		}
+	})
+}
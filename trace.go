@@ -0,0 +1,35 @@
+package stringsort
+
+import "sort"
+
+// WithTrace returns a sorter that behaves as s, but calls log with the
+// arguments and result of each call to its Less method. This is useful to
+// understand why a sort produced an unexpected order, for example:
+//
+//	sort.Sort(WithTrace(ByMixedKey(ss), func(i, j int, less bool) {
+//		log.Printf("Less(%q, %q) = %v", ss[i], ss[j], less)
+//	}))
+//
+// Note that log is called before the elements are swapped, so the offsets
+// refer to the positions of the elements at the time of the comparison.
+// If log == nil, WithTrace returns s unmodified, so there is no overhead
+// when tracing is not enabled.
+func WithTrace(s sort.Interface, log func(i, j int, less bool)) sort.Interface {
+	if log == nil {
+		return s
+	}
+	return traced{Interface: s, log: log}
+}
+
+// traced implements sort.Interface by delegating to an underlying sorter,
+// and reporting the results of comparisons to a callback.
+type traced struct {
+	sort.Interface
+	log func(i, j int, less bool)
+}
+
+func (t traced) Less(i, j int) bool {
+	less := t.Interface.Less(i, j)
+	t.log(i, j, less)
+	return less
+}
@@ -0,0 +1,35 @@
+package stringsort
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithTrace(t *testing.T) {
+	ss := []string{"x10", "x2", "x1"}
+	if _, ok := WithTrace(ByMixedKey(ss), nil).(traced); ok {
+		t.Error("WithTrace(s, nil): got a traced sorter, want s unmodified")
+	}
+
+	type call struct {
+		a, b string
+		less bool
+	}
+	var calls []call
+	sort.Sort(WithTrace(ByMixedKey(ss), func(i, j int, less bool) {
+		calls = append(calls, call{ss[i], ss[j], less})
+	}))
+	if diff := cmp.Diff([]string{"x1", "x2", "x10"}, ss); diff != "" {
+		t.Errorf("Sort: (-want, +got):\n%s", diff)
+	}
+	if len(calls) == 0 {
+		t.Fatal("No comparisons were traced")
+	}
+	for _, c := range calls {
+		if want := Compare(c.a, c.b) < 0; c.less != want {
+			t.Errorf("Traced Less(%q, %q): got %v, want %v", c.a, c.b, c.less, want)
+		}
+	}
+}